@@ -0,0 +1,89 @@
+package ssz
+
+import "github.com/succinctlabs/gnark-gadgets/vars"
+
+// basic is shorthand for the SSZType of any fixed-size basic value (uint64, Bytes32, ...): for
+// the purpose of computing generalized indices, all basic values look the same (one chunk).
+var basic = SSZType{Kind: KindBasic}
+
+// BeaconBlockHeader is the SSZ schema of a phase0 BeaconBlockHeader.
+var BeaconBlockHeader = SSZType{
+	Kind: KindContainer,
+	FieldNames: []string{
+		"slot", "proposer_index", "parent_root", "state_root", "body_root",
+	},
+	Fields: []SSZType{basic, basic, basic, basic, basic},
+}
+
+// BeaconBlockHeaderGIndex holds the pre-computed generalized index of each BeaconBlockHeader
+// field, relative to hash_tree_root(BeaconBlockHeader). Call
+// ComputeGeneralizedIndex(BeaconBlockHeader, ...) directly for any other path.
+var BeaconBlockHeaderGIndex = struct {
+	Slot          uint64
+	ProposerIndex uint64
+	ParentRoot    uint64
+	StateRoot     uint64
+	BodyRoot      uint64
+}{
+	Slot:          ComputeGeneralizedIndex(BeaconBlockHeader, []PathElement{Field("slot")}),
+	ProposerIndex: ComputeGeneralizedIndex(BeaconBlockHeader, []PathElement{Field("proposer_index")}),
+	ParentRoot:    ComputeGeneralizedIndex(BeaconBlockHeader, []PathElement{Field("parent_root")}),
+	StateRoot:     ComputeGeneralizedIndex(BeaconBlockHeader, []PathElement{Field("state_root")}),
+	BodyRoot:      ComputeGeneralizedIndex(BeaconBlockHeader, []PathElement{Field("body_root")}),
+}
+
+// validatorRegistryLimit bounds both the "validators" and "balances" lists of
+// BeaconStateFields. It matches the phase0 mainnet preset (VALIDATOR_REGISTRY_LIMIT); only
+// nextPowerOfTwo(chunkCount) matters for gindex computation, so an approximate but power-of-two
+// adjacent bound is fine for any preset.
+const validatorRegistryLimit = 1 << 40
+
+// BeaconStateFields is the SSZ schema of a phase0 BeaconState, listing every top-level field so
+// that field positions (and therefore generalized indices) are correct. Composite fields that
+// this package doesn't otherwise path into are given the placeholder `basic` schema; add a real
+// SSZType for a field before pathing into it.
+var BeaconStateFields = SSZType{
+	Kind: KindContainer,
+	FieldNames: []string{
+		"genesis_time", "genesis_validators_root", "slot", "fork", "latest_block_header",
+		"block_roots", "state_roots", "historical_roots", "eth1_data", "eth1_data_votes",
+		"eth1_deposit_index", "validators", "balances", "randao_mixes", "slashings",
+		"previous_epoch_attestations", "current_epoch_attestations", "justification_bits",
+		"previous_justified_checkpoint", "current_justified_checkpoint", "finalized_checkpoint",
+	},
+	Fields: []SSZType{
+		basic, basic, basic, basic, basic, // genesis_time .. latest_block_header
+		basic, basic, basic, basic, basic, // block_roots .. eth1_data_votes
+		basic, // eth1_deposit_index
+		{Kind: KindList, ElemType: &basic, Length: validatorRegistryLimit}, // validators
+		// balances is List[Gwei, N]: Gwei is a packed uint64, 4 per 32-byte chunk.
+		{Kind: KindList, ElemType: &basic, Length: validatorRegistryLimit, ElemSize: 8}, // balances
+		basic, basic, basic, basic, basic, // randao_mixes .. justification_bits
+		basic, basic, basic, // previous_justified_checkpoint .. finalized_checkpoint
+	},
+}
+
+// balancesItemsPerChunk is how many packed Gwei (uint64) values share one 32-byte `balances`
+// chunk.
+const balancesItemsPerChunk = 32 / 8
+
+// VerifyValidatorBalance proves that the Gwei value committed in balance sits at validatorIndex
+// in the beacon state's `balances` list, by verifying the 32-byte chunk packing four consecutive
+// balances (validatorIndex/4) against stateRoot, then asserting balance is the 8-byte slice of
+// that chunk at the validator's packed offset. The gindex of balances[validatorIndex] comes from a
+// ComputeGeneralizedIndex lookup on BeaconStateFields rather than being hand-computed.
+func (a *SimpleSerializeAPI) VerifyValidatorBalance(
+	stateRoot [32]vars.Byte,
+	chunk [32]vars.Byte,
+	balance [8]vars.Byte,
+	validatorIndex int,
+	proof [][32]vars.Byte,
+) {
+	gindex := ComputeGeneralizedIndex(BeaconStateFields, []PathElement{Field("balances"), Index(validatorIndex)})
+	a.VerifyProof(stateRoot, chunk, proof, int(gindex))
+
+	offset := (validatorIndex % balancesItemsPerChunk) * 8
+	for i := 0; i < 8; i++ {
+		a.api.FrontendAPI().AssertIsEqual(balance[i].Value, chunk[offset+i].Value)
+	}
+}
@@ -0,0 +1,164 @@
+package ssz
+
+import "fmt"
+
+// SSZKind identifies the shape of an SSZType.
+type SSZKind int
+
+const (
+	// KindBasic is a fixed-size basic value (e.g. uint64, Bytes32) that occupies a single chunk.
+	KindBasic SSZKind = iota
+	// KindContainer is a struct of named fields, each its own subtree.
+	KindContainer
+	// KindVector is a fixed-length homogeneous list.
+	KindVector
+	// KindList is a variable-length homogeneous list with a maximum length, mixed in at the root.
+	KindList
+	// KindBitlist is a variable-length list of bits, with the same length mix-in as KindList.
+	KindBitlist
+)
+
+// SSZType describes the shape of an SSZ value, just well enough to compute generalized indices
+// for its fields via ComputeGeneralizedIndex. It is not a full SSZ schema: it does not describe
+// byte sizes, beyond ElemSize, which a Vector/List needs to locate the chunk (and, within that
+// chunk, the sub-slice) a packed basic-type element lives at.
+type SSZType struct {
+	Kind SSZKind
+
+	// Fields and FieldNames describe a KindContainer's fields, in declaration order.
+	Fields     []SSZType
+	FieldNames []string
+
+	// ElemType describes the element type of a KindVector, KindList, or KindBitlist.
+	ElemType *SSZType
+
+	// Length is the fixed length of a KindVector, or the maximum length of a KindList/KindBitlist.
+	Length int
+
+	// ElemSize is the packed byte-size of a basic ElemType (e.g. 8 for uint64), set when a
+	// Vector/List packs multiple elements per 32-byte chunk. Zero means ElemType is composite (or
+	// otherwise occupies a whole chunk per element), the common case this package otherwise
+	// assumes.
+	ElemSize int
+}
+
+// PathElement is one step into an SSZType: either a container field name or a vector/list index.
+// Use the length sentinel produced by LengthOf to path into a List/Bitlist's length mix-in.
+type PathElement struct {
+	field    string
+	index    int
+	isField  bool
+	isLength bool
+}
+
+// Field builds a PathElement that selects a container field by name.
+func Field(name string) PathElement {
+	return PathElement{field: name, isField: true}
+}
+
+// Index builds a PathElement that selects a vector/list element by index.
+func Index(i int) PathElement {
+	return PathElement{index: i}
+}
+
+// LengthOf builds a PathElement that selects the length mix-in of a List or Bitlist.
+func LengthOf() PathElement {
+	return PathElement{isLength: true}
+}
+
+// ComputeGeneralizedIndex computes the generalized index of the value reached by following path
+// through schema, relative to hash_tree_root(schema). See the SSZ spec's get_generalized_index.
+func ComputeGeneralizedIndex(schema SSZType, path []PathElement) uint64 {
+	root := uint64(1)
+	typ := schema
+
+	for _, p := range path {
+		if p.isLength {
+			if typ.Kind != KindList && typ.Kind != KindBitlist {
+				panic("__len__ is only valid on a List or Bitlist")
+			}
+			root = root*2 + 1
+			continue
+		}
+
+		var pos int
+		var next SSZType
+		base := uint64(1)
+
+		switch typ.Kind {
+		case KindContainer:
+			pos = fieldIndex(typ, p.field)
+			next = typ.Fields[pos]
+		case KindVector:
+			pos = elemChunkIndex(typ, p.index)
+			next = *typ.ElemType
+		case KindList, KindBitlist:
+			pos = elemChunkIndex(typ, p.index)
+			next = *typ.ElemType
+			// Lists mix in a length chunk alongside the data subtree, at generalized index 3
+			// (3 = 2*1+1), so the data subtree itself starts at generalized index 2.
+			base = 2
+		default:
+			panic("cannot path into a basic SSZType")
+		}
+
+		root = root*nextPowerOfTwo(uint64(chunkCount(typ)))*base + uint64(pos)
+		typ = next
+	}
+
+	return root
+}
+
+func fieldIndex(typ SSZType, name string) int {
+	for i, fieldName := range typ.FieldNames {
+		if fieldName == name {
+			return i
+		}
+	}
+	panic(fmt.Sprintf("no field named %q", name))
+}
+
+func chunkCount(typ SSZType) int {
+	switch typ.Kind {
+	case KindBasic:
+		return 1
+	case KindContainer:
+		return len(typ.Fields)
+	case KindBitlist:
+		// A Bitlist packs 256 bits into each 32-byte chunk.
+		return (typ.Length + 255) / 256
+	case KindVector, KindList:
+		if typ.ElemSize > 0 {
+			itemsPerChunk := 32 / typ.ElemSize
+			return (typ.Length + itemsPerChunk - 1) / itemsPerChunk
+		}
+		return typ.Length
+	default:
+		panic("unknown SSZKind")
+	}
+}
+
+// elemChunkIndex returns the chunk index holding element index within typ: itself for a
+// one-element-per-chunk Vector/List, or index/itemsPerChunk when typ packs ElemSize-byte elements
+// several to a chunk, or index/256 for a Bitlist's bits.
+func elemChunkIndex(typ SSZType, index int) int {
+	if typ.Kind == KindBitlist {
+		return index / 256
+	}
+	if typ.ElemSize > 0 {
+		itemsPerChunk := 32 / typ.ElemSize
+		return index / itemsPerChunk
+	}
+	return index
+}
+
+func nextPowerOfTwo(n uint64) uint64 {
+	if n <= 1 {
+		return 1
+	}
+	p := uint64(1)
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
@@ -0,0 +1,66 @@
+package ssz
+
+import "testing"
+
+// TestComputeGeneralizedIndexPackedBasic covers ComputeGeneralizedIndex's packed-basic-chunking
+// path (ElemSize > 0): four consecutive validatorIndex values must land in the same gindex
+// (shared 32-byte chunk, four 8-byte Gwei values packed per chunk), and every fifth index must
+// advance to the next gindex.
+func TestComputeGeneralizedIndexPackedBasic(t *testing.T) {
+	balances := Field("balances")
+
+	cases := []struct {
+		validatorIndex int
+		wantGIndex     uint64
+	}{
+		{0, ComputeGeneralizedIndex(BeaconStateFields, []PathElement{balances, Index(0)})},
+		{1, ComputeGeneralizedIndex(BeaconStateFields, []PathElement{balances, Index(0)})},
+		{2, ComputeGeneralizedIndex(BeaconStateFields, []PathElement{balances, Index(0)})},
+		{3, ComputeGeneralizedIndex(BeaconStateFields, []PathElement{balances, Index(0)})},
+		{4, ComputeGeneralizedIndex(BeaconStateFields, []PathElement{balances, Index(4)})},
+	}
+
+	for _, c := range cases {
+		got := ComputeGeneralizedIndex(BeaconStateFields, []PathElement{balances, Index(c.validatorIndex)})
+		if got != c.wantGIndex {
+			t.Errorf("balances[%d]: got gindex %d, want %d", c.validatorIndex, got, c.wantGIndex)
+		}
+	}
+
+	gindex0 := ComputeGeneralizedIndex(BeaconStateFields, []PathElement{balances, Index(0)})
+	gindex4 := ComputeGeneralizedIndex(BeaconStateFields, []PathElement{balances, Index(4)})
+	if gindex4 != gindex0+1 {
+		t.Errorf("balances[4] should be the chunk right after balances[0..3]'s, got %d and %d", gindex0, gindex4)
+	}
+}
+
+// TestComputeGeneralizedIndexUnpacked covers the non-packed path (ElemSize == 0): each index gets
+// its own chunk, one gindex apart.
+func TestComputeGeneralizedIndexUnpacked(t *testing.T) {
+	validators := Field("validators")
+
+	gindex0 := ComputeGeneralizedIndex(BeaconStateFields, []PathElement{validators, Index(0)})
+	gindex1 := ComputeGeneralizedIndex(BeaconStateFields, []PathElement{validators, Index(1)})
+	if gindex1 != gindex0+1 {
+		t.Errorf("validators[1] should be exactly one gindex after validators[0], got %d and %d", gindex0, gindex1)
+	}
+}
+
+// TestComputeGeneralizedIndexLengthMixin covers the __len__ path on a packed List, which must
+// land at 2*parent+1 regardless of ElemSize.
+func TestComputeGeneralizedIndexLengthMixin(t *testing.T) {
+	parent := ComputeGeneralizedIndex(BeaconStateFields, []PathElement{Field("balances")})
+	length := ComputeGeneralizedIndex(BeaconStateFields, []PathElement{Field("balances"), LengthOf()})
+	if length != 2*parent+1 {
+		t.Errorf("balances.__len__: got gindex %d, want %d", length, 2*parent+1)
+	}
+}
+
+// TestBeaconBlockHeaderGIndex checks that the precomputed BeaconBlockHeaderGIndex.StateRoot entry
+// matches ComputeGeneralizedIndex run directly on the "state_root" field.
+func TestBeaconBlockHeaderGIndex(t *testing.T) {
+	want := ComputeGeneralizedIndex(BeaconBlockHeader, []PathElement{Field("state_root")})
+	if BeaconBlockHeaderGIndex.StateRoot != want {
+		t.Errorf("BeaconBlockHeaderGIndex.StateRoot = %d, want %d", BeaconBlockHeaderGIndex.StateRoot, want)
+	}
+}
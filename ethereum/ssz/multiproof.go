@@ -0,0 +1,77 @@
+package ssz
+
+import (
+	"sort"
+
+	"github.com/succinctlabs/gnark-gadgets/hash/sha256"
+	"github.com/succinctlabs/gnark-gadgets/vars"
+)
+
+// VerifyMultiProof verifies several leaves against a single root in one call, sharing whatever
+// part of their Merkle branches overlap. This is the standard SSZ multiproof algorithm: compute
+// the "helper" generalized indices H that are siblings on the path to some gindex in gindices but
+// aren't themselves in gindices or an ancestor of one, sort gindices ∪ H by descending gindex,
+// then repeatedly pop the pair (2k+1, 2k) and push (k, sha256(left||right)) until only gindex 1
+// (the root) remains.
+//
+// leaves and gindices must be parallel (leaves[i] sits at gindices[i]), as must branch and
+// branchIndices; branchIndices must be exactly the helper indices H described above, supplied by
+// the prover in descending order of gindex is not required, VerifyMultiProof sorts them itself.
+func (a *SimpleSerializeAPI) VerifyMultiProof(
+	root [32]vars.Byte,
+	leaves [][32]vars.Byte,
+	gindices []int,
+	branch [][32]vars.Byte,
+	branchIndices []int,
+) {
+	if len(leaves) != len(gindices) {
+		panic("leaves and gindices must have the same length")
+	}
+	if len(branch) != len(branchIndices) {
+		panic("branch and branchIndices must have the same length")
+	}
+
+	nodes := make(map[int][32]vars.Byte, len(leaves)+len(branch))
+	for i, gindex := range gindices {
+		nodes[gindex] = leaves[i]
+	}
+	for i, gindex := range branchIndices {
+		nodes[gindex] = branch[i]
+	}
+
+	keys := make([]int, 0, len(nodes))
+	for gindex := range nodes {
+		keys = append(keys, gindex)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(keys)))
+
+	for pos := 0; pos < len(keys); pos++ {
+		k := keys[pos]
+		if k == 1 {
+			break
+		}
+
+		node, haveNode := nodes[k]
+		sibling, haveSibling := nodes[k^1]
+		if _, haveParent := nodes[k/2]; !haveNode || !haveSibling || haveParent {
+			continue
+		}
+
+		var left, right [32]vars.Byte
+		if k%2 == 0 {
+			left, right = node, sibling
+		} else {
+			left, right = sibling, node
+		}
+		nodes[k/2] = sha256.Hash(a.api, append(left[:], right[:]...))
+		keys = append(keys, k/2)
+	}
+
+	restoredRoot, ok := nodes[1]
+	if !ok {
+		panic("multiproof does not resolve to gindex 1; gindices/branchIndices are inconsistent")
+	}
+	for i := 0; i < 32; i++ {
+		a.api.FrontendAPI().AssertIsEqual(root[i].Value, restoredRoot[i].Value)
+	}
+}
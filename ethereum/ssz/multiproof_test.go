@@ -0,0 +1,88 @@
+package ssz_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	gadgetssuccinct "github.com/succinctlabs/gnark-gadgets/succinct"
+	"github.com/succinctlabs/gnark-gadgets/vars"
+	"github.com/succinctlabs/sdk/ethereum/ssz"
+)
+
+// multiProofCircuit verifies a 2-leaf, 1-helper multiproof: leaves at gindices 4 and 5 share
+// parent 2, whose sibling 3 is supplied as the only helper node, so root = sha256(sha256(leaf4 ||
+// leaf5) || node3). This mirrors the sibling/helper resolution VerifyMultiProof would do for two
+// adjacent balances chunks (e.g. balances[0..3] and balances[4..7]), at a tree depth small enough
+// to hand-compute in a test.
+type multiProofCircuit struct {
+	Root  [32]frontend.Variable
+	Leaf4 [32]frontend.Variable
+	Leaf5 [32]frontend.Variable
+	Node3 [32]frontend.Variable
+}
+
+func (c *multiProofCircuit) Define(baseApi frontend.API) error {
+	api := gadgetssuccinct.NewAPI(baseApi)
+	sszApi := ssz.NewAPI(api)
+
+	toBytes32 := func(v [32]frontend.Variable) [32]vars.Byte {
+		var out [32]vars.Byte
+		for i := range v {
+			out[i] = vars.Byte{Value: v[i]}
+		}
+		return out
+	}
+
+	sszApi.VerifyMultiProof(
+		toBytes32(c.Root),
+		[][32]vars.Byte{toBytes32(c.Leaf4), toBytes32(c.Leaf5)},
+		[]int{4, 5},
+		[][32]vars.Byte{toBytes32(c.Node3)},
+		[]int{3},
+	)
+	return nil
+}
+
+// TestVerifyMultiProofResolvesSharedParent checks that VerifyMultiProof accepts a multiproof
+// whose two leaves share a parent (no helper node needed at that level) and resolves the
+// remaining helper up to the root.
+func TestVerifyMultiProofResolvesSharedParent(t *testing.T) {
+	leaf4 := sha256.Sum256([]byte("leaf4"))
+	leaf5 := sha256.Sum256([]byte("leaf5"))
+	node3 := sha256.Sum256([]byte("node3"))
+
+	node2 := sha256.Sum256(append(append([]byte{}, leaf4[:]...), leaf5[:]...))
+	root := sha256.Sum256(append(append([]byte{}, node2[:]...), node3[:]...))
+
+	toVars := func(b [32]byte) [32]frontend.Variable {
+		var out [32]frontend.Variable
+		for i, x := range b {
+			out[i] = x
+		}
+		return out
+	}
+
+	assignment := &multiProofCircuit{
+		Root:  toVars(root),
+		Leaf4: toVars(leaf4),
+		Leaf5: toVars(leaf5),
+		Node3: toVars(node3),
+	}
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &multiProofCircuit{})
+	if err != nil {
+		t.Fatalf("failed to compile circuit: %v", err)
+	}
+
+	witness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("failed to create witness: %v", err)
+	}
+
+	if err := ccs.IsSolved(witness); err != nil {
+		t.Fatalf("multiproof failed to resolve to root: %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+// Package poseidon2 commits to arbitrary-length byte slices with the Poseidon2 permutation over
+// the BN254 scalar field, for use as a cheaper alternative to SHA256 inside circuits that don't
+// need onchain SHA256 compatibility (see succinct.Poseidon2BN254).
+package poseidon2
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	gnarkposeidon2 "github.com/consensys/gnark-crypto/ecc/bn254/fr/poseidon2"
+	"github.com/consensys/gnark/frontend"
+	circuitposeidon2 "github.com/consensys/gnark/std/permutation/poseidon2"
+	"github.com/succinctlabs/sdk/gnarkx/builder"
+	"github.com/succinctlabs/sdk/gnarkx/vars"
+)
+
+// width, nbFullRounds, and nbPartialRounds are BN254's standard Poseidon2 parameters (a t=2
+// sponge: one rate element, one capacity element). HashBytes and HashBytesHost both instantiate
+// the permutation with these same parameters, so they always agree on the resulting digest.
+const (
+	width           = 2
+	nbFullRounds    = 8
+	nbPartialRounds = 56
+)
+
+// bytesPerChunk is how many bytes are absorbed per permutation call. 31 bytes is 248 bits, safely
+// under the BN254 scalar field's ~254-bit modulus, so a chunk's big-endian value is always already
+// reduced and absorbing it never needs a modular-reduction step of its own.
+const bytesPerChunk = 31
+
+// chunks splits data into bytesPerChunk-byte big-endian pieces, zero-padding the final piece so
+// HashBytes/HashBytesHost never special-case a short last chunk. Empty data hashes as a single
+// all-zero chunk, matching an empty sponge absorption.
+func chunks(data []byte) [][]byte {
+	var out [][]byte
+	for i := 0; i < len(data); i += bytesPerChunk {
+		end := i + bytesPerChunk
+		if end > len(data) {
+			end = len(data)
+		}
+		chunk := make([]byte, bytesPerChunk)
+		copy(chunk, data[i:end])
+		out = append(out, chunk)
+	}
+	if len(out) == 0 {
+		out = append(out, make([]byte, bytesPerChunk))
+	}
+	return out
+}
+
+// HashBytesHost returns the Poseidon2 sponge digest of data as a single BN254 scalar field
+// element: absorb one bytesPerChunk-byte chunk per permutation call into the rate element, then
+// return the final rate element as the digest. HashBytes computes the identical value in-circuit.
+func HashBytesHost(data []byte) *big.Int {
+	state := make([]fr.Element, width)
+	perm := gnarkposeidon2.NewPermutation(width, nbFullRounds, nbPartialRounds)
+
+	for _, chunk := range chunks(data) {
+		var absorbed fr.Element
+		absorbed.SetBytes(chunk)
+		state[0].Add(&state[0], &absorbed)
+
+		if err := perm.Permutation(state); err != nil {
+			panic(err)
+		}
+	}
+
+	digest := new(big.Int)
+	state[0].BigInt(digest)
+	return digest
+}
+
+// HashBytes is HashBytesHost's in-circuit counterpart: same chunking, same absorb-then-permute
+// sponge, over circuit variables instead of concrete field elements.
+func HashBytes(api builder.API, data []vars.Byte) vars.Variable {
+	fapi := api.FrontendAPI()
+	perm := circuitposeidon2.NewPermutation(fapi, width, nbFullRounds, nbPartialRounds)
+
+	state := make([]frontend.Variable, width)
+	for i := range state {
+		state[i] = 0
+	}
+
+	for _, chunk := range paddedByteChunks(data) {
+		state[0] = fapi.Add(state[0], bytesToVariable(fapi, chunk))
+
+		next, err := perm.Permutation(state)
+		if err != nil {
+			panic(err)
+		}
+		state = next
+	}
+
+	return vars.Variable{Value: state[0]}
+}
+
+// paddedByteChunks splits data into bytesPerChunk-byte slices, right-padding the final slice with
+// the constant 0 (never a fresh witness byte, which a malicious prover could set to anything) so
+// it matches chunks' host-side zero padding.
+func paddedByteChunks(data []vars.Byte) [][]vars.Byte {
+	padded := make([]vars.Byte, len(data))
+	copy(padded, data)
+	for len(padded)%bytesPerChunk != 0 {
+		padded = append(padded, vars.Byte{Value: 0})
+	}
+	if len(padded) == 0 {
+		padded = make([]vars.Byte, bytesPerChunk)
+		for i := range padded {
+			padded[i] = vars.Byte{Value: 0}
+		}
+	}
+
+	out := make([][]vars.Byte, 0, len(padded)/bytesPerChunk)
+	for i := 0; i < len(padded); i += bytesPerChunk {
+		out = append(out, padded[i:i+bytesPerChunk])
+	}
+	return out
+}
+
+// bytesToVariable reassembles a big-endian byte chunk into a single field element via Horner's
+// method, matching fr.Element.SetBytes' big-endian convention on the host side.
+func bytesToVariable(api frontend.API, chunk []vars.Byte) frontend.Variable {
+	v := frontend.Variable(0)
+	for _, b := range chunk {
+		v = api.Add(api.Mul(v, 256), b.Value)
+	}
+	return v
+}
@@ -0,0 +1,62 @@
+package poseidon2_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/succinctlabs/sdk/gnarkx/builder"
+	"github.com/succinctlabs/sdk/gnarkx/hash/poseidon2"
+	"github.com/succinctlabs/sdk/gnarkx/vars"
+)
+
+// hashCircuit asserts that poseidon2.HashBytes(Data) == Digest, so TestHashBytesMatchesHost can
+// check the in-circuit and host implementations agree on a concrete assignment.
+type hashCircuit struct {
+	Data   [40]frontend.Variable
+	Digest frontend.Variable `gnark:",public"`
+}
+
+func (c *hashCircuit) Define(baseApi frontend.API) error {
+	api := builder.NewAPI(baseApi)
+	data := make([]vars.Byte, len(c.Data))
+	for i := range c.Data {
+		data[i] = vars.Byte{Value: c.Data[i]}
+	}
+
+	digest := poseidon2.HashBytes(*api, data)
+	baseApi.AssertIsEqual(c.Digest, digest.Value)
+	return nil
+}
+
+// TestHashBytesMatchesHost checks that HashBytes (in-circuit) and HashBytesHost (host) produce
+// the same digest for an input that spans more than one bytesPerChunk-sized permutation call.
+func TestHashBytesMatchesHost(t *testing.T) {
+	data := make([]byte, 40)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	want := poseidon2.HashBytesHost(data)
+
+	var assignment hashCircuit
+	for i, b := range data {
+		assignment.Data[i] = b
+	}
+	assignment.Digest = want
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &hashCircuit{})
+	if err != nil {
+		t.Fatalf("failed to compile circuit: %v", err)
+	}
+
+	witness, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("failed to create witness: %v", err)
+	}
+
+	if err := ccs.IsSolved(witness); err != nil {
+		t.Fatalf("in-circuit digest did not match host digest: %v", err)
+	}
+}
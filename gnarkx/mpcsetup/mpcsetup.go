@@ -0,0 +1,157 @@
+// Package mpcsetup implements a file-based, multi-party Groth16 trusted setup ceremony for
+// circuits built via succinct.CircuitFunction. (*CircuitFunction).Build runs groth16.Setup
+// directly, which is only safe for testing: whoever ran it learns toxic waste that would let them
+// forge proofs. A Phase-2 MPC ceremony spreads that trust across many participants, so the setup
+// is only compromised if every single one of them colluded.
+//
+// A ceremony has two phases. Phase 1 ("powers of tau") is circuit-independent and can be reused
+// across circuits; each participant contributes randomness on top of the previous contribution.
+// Phase 2 specializes the phase 1 transcript to a specific R1CS and is likewise contributed to in
+// sequence. Finalize then derives a groth16.ProvingKey/groth16.VerifyingKey pair that is
+// byte-compatible with the keys (*CircuitBuild).Export already writes, so the existing
+// FunctionVerifier.sol exporter and CircuitFunction.Prove need no changes.
+package mpcsetup
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/consensys/gnark/backend/groth16"
+	bn254mpcsetup "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	"github.com/consensys/gnark/constraint"
+)
+
+// Phase1 is a single contribution to the circuit-independent "powers of tau" transcript.
+type Phase1 struct {
+	inner bn254mpcsetup.Phase1
+
+	// TranscriptHash is a hash of this contribution, published so that later participants and
+	// auditors can verify a specific contribution was included in the final ceremony.
+	TranscriptHash []byte
+}
+
+// InitPhase1 starts a new phase 1 transcript for a circuit with up to 2^power constraints.
+func InitPhase1(power int) (*Phase1, error) {
+	phase1 := &Phase1{}
+	phase1.inner.Initialize(1 << power)
+	phase1.TranscriptHash = phase1.inner.Hash()
+	return phase1, nil
+}
+
+// ContributePhase1 adds a new participant's randomness and proof-of-knowledge on top of prev,
+// returning the next transcript in the ceremony. prev is left untouched so that it can still be
+// persisted and audited independently of the new contribution.
+func ContributePhase1(prev *Phase1) (*Phase1, error) {
+	next := &Phase1{inner: prev.inner.Clone()}
+	next.inner.Contribute()
+	next.TranscriptHash = next.inner.Hash()
+	return next, nil
+}
+
+// VerifyPhase1 checks that next's proof-of-knowledge correctly extends prev's transcript.
+func VerifyPhase1(prev, next *Phase1) error {
+	return bn254mpcsetup.VerifyPhase1(&prev.inner, &next.inner)
+}
+
+// WritePhase1 persists a phase 1 contribution to path.
+func WritePhase1(path string, phase1 *Phase1) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := phase1.inner.WriteTo(f); err != nil {
+		return fmt.Errorf("failed to write phase 1 contribution: %w", err)
+	}
+	return nil
+}
+
+// ReadPhase1 loads a phase 1 contribution previously written by WritePhase1.
+func ReadPhase1(path string) (*Phase1, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	phase1 := &Phase1{}
+	if _, err := phase1.inner.ReadFrom(f); err != nil {
+		return nil, fmt.Errorf("failed to read phase 1 contribution: %w", err)
+	}
+	phase1.TranscriptHash = phase1.inner.Hash()
+	return phase1, nil
+}
+
+// Phase2 is a single contribution to the circuit-specific half of the ceremony.
+type Phase2 struct {
+	inner bn254mpcsetup.Phase2
+
+	// TranscriptHash is a hash of this contribution, published for audit purposes.
+	TranscriptHash []byte
+}
+
+// InitPhase2 specializes phase1 to r1cs, starting a new phase 2 transcript.
+func InitPhase2(phase1 *Phase1, r1cs constraint.ConstraintSystem) (*Phase2, error) {
+	phase2 := &Phase2{}
+	if err := phase2.inner.Initialize(r1cs, &phase1.inner); err != nil {
+		return nil, fmt.Errorf("failed to initialize phase 2: %w", err)
+	}
+	phase2.TranscriptHash = phase2.inner.Hash()
+	return phase2, nil
+}
+
+// ContributePhase2 adds a new participant's randomness and proof-of-knowledge on top of prev.
+func ContributePhase2(prev *Phase2) (*Phase2, error) {
+	next := &Phase2{inner: prev.inner.Clone()}
+	next.inner.Contribute()
+	next.TranscriptHash = next.inner.Hash()
+	return next, nil
+}
+
+// VerifyPhase2 checks that next's proof-of-knowledge correctly extends prev's transcript.
+func VerifyPhase2(prev, next *Phase2) error {
+	return bn254mpcsetup.VerifyPhase2(&prev.inner, &next.inner)
+}
+
+// WritePhase2 persists a phase 2 contribution to path.
+func WritePhase2(path string, phase2 *Phase2) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := phase2.inner.WriteTo(f); err != nil {
+		return fmt.Errorf("failed to write phase 2 contribution: %w", err)
+	}
+	return nil
+}
+
+// ReadPhase2 loads a phase 2 contribution previously written by WritePhase2.
+func ReadPhase2(path string) (*Phase2, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	phase2 := &Phase2{}
+	if _, err := phase2.inner.ReadFrom(f); err != nil {
+		return nil, fmt.Errorf("failed to read phase 2 contribution: %w", err)
+	}
+	phase2.TranscriptHash = phase2.inner.Hash()
+	return phase2, nil
+}
+
+// Finalize derives the proving and verifying keys from the final phase 1 and phase 2
+// contributions. The resulting keys are written with the same groth16.ProvingKey/
+// groth16.VerifyingKey types (*CircuitBuild).Export uses, so they plug directly into the existing
+// Export/ImportCircuitBuild and Solidity exporter.
+func Finalize(phase1 *Phase1, phase2 *Phase2, r1cs constraint.ConstraintSystem) (groth16.ProvingKey, groth16.VerifyingKey, error) {
+	pk, vk, err := phase2.inner.Seed(&phase1.inner, r1cs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to extract keys from ceremony: %w", err)
+	}
+	return pk, vk, nil
+}
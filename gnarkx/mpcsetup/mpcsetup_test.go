@@ -0,0 +1,125 @@
+package mpcsetup_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/succinctlabs/sdk/gnarkx/mpcsetup"
+	"github.com/succinctlabs/sdk/gnarkx/succinct"
+	"github.com/succinctlabs/sdk/gnarkx/vars"
+)
+
+// multiplyCircuit asserts A*B == C, wired as a minimal succinct.Circuit so
+// TestCeremonyWithThreeParticipants drives the ceremony's output through CircuitFunction's own
+// compile/prove/InputHash wiring instead of a bare frontend.Compile/groth16.Prove.
+type multiplyCircuit struct {
+	A, B, C frontend.Variable
+
+	inputBytes  []vars.Byte
+	outputBytes []vars.Byte
+}
+
+func (circuit *multiplyCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(circuit.A, circuit.B), circuit.C)
+	return nil
+}
+
+// SetWitness assigns A and B from inputBytes (a || b, 32 bytes each) and computes C = A*B.
+func (circuit *multiplyCircuit) SetWitness(inputBytes []byte) {
+	if len(inputBytes) != 64 {
+		panic("multiplyCircuit input bytes must be exactly a || b (64 bytes)")
+	}
+	vars.SetBytes(&circuit.inputBytes, inputBytes)
+
+	a := new(big.Int).SetBytes(inputBytes[:32])
+	b := new(big.Int).SetBytes(inputBytes[32:])
+	c := new(big.Int).Mul(a, b)
+
+	circuit.A = a
+	circuit.B = b
+	circuit.C = c
+
+	var outputBytes [32]byte
+	c.FillBytes(outputBytes[:])
+	vars.SetBytes(&circuit.outputBytes, outputBytes[:])
+}
+
+func (circuit *multiplyCircuit) GetInputBytes() *[]vars.Byte  { return &circuit.inputBytes }
+func (circuit *multiplyCircuit) GetOutputBytes() *[]vars.Byte { return &circuit.outputBytes }
+
+// TestCeremonyWithThreeParticipants runs a phase 1 and phase 2 ceremony with three simulated
+// participants each, finalizes the keys, and checks that CircuitFunction.Prove succeeds against
+// the finalized keys and that the finalized verifying key accepts a proof of the
+// CircuitFunction-wrapped circuit — the integration point a hand-rolled trivialCircuit run through
+// raw frontend.Compile/groth16.Prove doesn't exercise.
+func TestCeremonyWithThreeParticipants(t *testing.T) {
+	function := succinct.NewCircuitFunction(&multiplyCircuit{})
+	ccs, err := function.Compile()
+	if err != nil {
+		t.Fatalf("failed to compile circuit: %v", err)
+	}
+
+	phase1, err := mpcsetup.InitPhase1(10)
+	if err != nil {
+		t.Fatalf("failed to init phase 1: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		phase1, err = mpcsetup.ContributePhase1(phase1)
+		if err != nil {
+			t.Fatalf("participant %d failed to contribute to phase 1: %v", i, err)
+		}
+	}
+
+	phase2, err := mpcsetup.InitPhase2(phase1, ccs)
+	if err != nil {
+		t.Fatalf("failed to init phase 2: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		phase2, err = mpcsetup.ContributePhase2(phase2)
+		if err != nil {
+			t.Fatalf("participant %d failed to contribute to phase 2: %v", i, err)
+		}
+	}
+
+	pk, vk, err := mpcsetup.Finalize(phase1, phase2, ccs)
+	if err != nil {
+		t.Fatalf("failed to finalize ceremony: %v", err)
+	}
+
+	build := succinct.NewCircuitBuild(ccs, pk, vk, succinct.Sha256Truncated253{})
+
+	a, b := big.NewInt(3), big.NewInt(5)
+	var inputBytes [64]byte
+	a.FillBytes(inputBytes[:32])
+	b.FillBytes(inputBytes[32:])
+
+	proof, err := function.Prove(inputBytes[:], build)
+	if err != nil {
+		t.Fatalf("failed to prove against the finalized ceremony keys: %v", err)
+	}
+	if proof == nil {
+		t.Fatal("expected a non-nil groth16 proof")
+	}
+
+	// Prove doesn't hand back the raw groth16.Proof it built internally, so reproduce it here
+	// (function already holds the InputHash/OutputHash/witness the Prove call above assigned) to
+	// check that the finalized verifying key actually accepts a proof of this exact circuit.
+	witness, err := frontend.NewWitness(&function, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("failed to create witness: %v", err)
+	}
+	rawProof, err := groth16.Prove(ccs, pk, witness)
+	if err != nil {
+		t.Fatalf("failed to reprove for verification: %v", err)
+	}
+	publicWitness, err := witness.Public()
+	if err != nil {
+		t.Fatalf("failed to extract public witness: %v", err)
+	}
+	if err := groth16.Verify(rawProof, vk, publicWitness); err != nil {
+		t.Fatalf("finalized ceremony key failed to verify a proof of the CircuitFunction-wrapped circuit: %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package mpcsetup
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Phase1FileName and Phase2FileName are the contribution file names VerifyContributions expects
+// to find under a ceremony directory, in contribution order (phase1.0.bin, phase1.1.bin, ...).
+const (
+	Phase1FileName = "phase1"
+	Phase2FileName = "phase2"
+)
+
+// VerifyContributions re-executes every published proof-of-knowledge in a ceremony directory, so
+// that an auditor who was not present for the ceremony can still confirm every contribution was
+// applied honestly. dir must contain phase1.0.bin..phase1.N.bin (the initial transcript plus each
+// participant's contribution) and, once phase 2 has started, phase2.0.bin..phase2.M.bin.
+func VerifyContributions(dir string) error {
+	phase1Paths, err := contributionPaths(dir, Phase1FileName)
+	if err != nil {
+		return err
+	}
+	if len(phase1Paths) < 1 {
+		return fmt.Errorf("no phase 1 contributions found in %s", dir)
+	}
+
+	prev, err := ReadPhase1(phase1Paths[0])
+	if err != nil {
+		return fmt.Errorf("failed to read initial phase 1 transcript: %w", err)
+	}
+	fmt.Printf("phase1.0: %s\n", hex.EncodeToString(prev.TranscriptHash))
+
+	for i := 1; i < len(phase1Paths); i++ {
+		next, err := ReadPhase1(phase1Paths[i])
+		if err != nil {
+			return fmt.Errorf("failed to read phase 1 contribution %d: %w", i, err)
+		}
+		if err := VerifyPhase1(prev, next); err != nil {
+			return fmt.Errorf("phase 1 contribution %d failed verification: %w", i, err)
+		}
+		fmt.Printf("phase1.%d: %s (verified)\n", i, hex.EncodeToString(next.TranscriptHash))
+		prev = next
+	}
+
+	phase2Paths, err := contributionPaths(dir, Phase2FileName)
+	if err != nil {
+		return err
+	}
+	if len(phase2Paths) == 0 {
+		return nil
+	}
+
+	prev2, err := ReadPhase2(phase2Paths[0])
+	if err != nil {
+		return fmt.Errorf("failed to read initial phase 2 transcript: %w", err)
+	}
+	fmt.Printf("phase2.0: %s\n", hex.EncodeToString(prev2.TranscriptHash))
+
+	for i := 1; i < len(phase2Paths); i++ {
+		next2, err := ReadPhase2(phase2Paths[i])
+		if err != nil {
+			return fmt.Errorf("failed to read phase 2 contribution %d: %w", i, err)
+		}
+		if err := VerifyPhase2(prev2, next2); err != nil {
+			return fmt.Errorf("phase 2 contribution %d failed verification: %w", i, err)
+		}
+		fmt.Printf("phase2.%d: %s (verified)\n", i, hex.EncodeToString(next2.TranscriptHash))
+		prev2 = next2
+	}
+
+	return nil
+}
+
+// contributionPaths returns the "<prefix>.<n>.bin" files under dir, sorted by contribution index.
+func contributionPaths(dir, prefix string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ceremony directory: %w", err)
+	}
+
+	var indices []int
+	paths := map[int]string{}
+	for _, entry := range entries {
+		var index int
+		if _, err := fmt.Sscanf(entry.Name(), prefix+".%d.bin", &index); err != nil {
+			continue
+		}
+		indices = append(indices, index)
+		paths[index] = filepath.Join(dir, entry.Name())
+	}
+	sort.Ints(indices)
+
+	ordered := make([]string, len(indices))
+	for i, index := range indices {
+		ordered[i] = paths[index]
+	}
+	return ordered, nil
+}
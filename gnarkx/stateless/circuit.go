@@ -0,0 +1,104 @@
+package stateless
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/succinctlabs/sdk/gnarkx/builder"
+	"github.com/succinctlabs/sdk/gnarkx/vars"
+)
+
+// ExecutionWitness is a single stateless witness: enough MPT proof nodes to verify every pre-state
+// slot a block's execution reads, plus the resulting (key, value) writes, to check that replaying
+// the block against preStateRoot yields postStateRoot without needing a full archive node.
+type ExecutionWitness struct {
+	Proofs []Proof
+	Writes []Write
+}
+
+// Circuit verifies an ExecutionWitness against a claimed pre-state root, post-state root, and
+// block hash. It implements succinct.Circuit, so it plugs into succinct.NewCircuitFunction and
+// the existing CLI prove/verify flow and Solidity exporter unchanged; PreStateRoot, PostStateRoot,
+// and BlockHash are committed through CircuitFunction's usual InputHash/OutputHash scheme, with
+// PreStateRoot and BlockHash as inputs and PostStateRoot as the output.
+type Circuit struct {
+	PreStateRoot  [32]vars.Byte
+	PostStateRoot [32]vars.Byte
+	BlockHash     [32]vars.Byte
+
+	// HeaderBytes is the RLP-encoded block header BlockHash is claimed to be the keccak of.
+	// Define binds BlockHash to these bytes so it can't be swapped for an unrelated block's hash;
+	// it does not (yet) cross-check the header's own state-root field against
+	// PreStateRoot/PostStateRoot, since that needs a block-header RLP field decoder this package
+	// doesn't have.
+	HeaderBytes []vars.Byte
+
+	Witness ExecutionWitness
+
+	inputBytes  []vars.Byte
+	outputBytes []vars.Byte
+}
+
+// NewCircuit creates a Circuit that is ready to have its witness assigned with SetWitness.
+func NewCircuit() *Circuit {
+	c := &Circuit{}
+	for i := 0; i < 32; i++ {
+		c.PreStateRoot[i] = vars.NewByte()
+		c.PostStateRoot[i] = vars.NewByte()
+		c.BlockHash[i] = vars.NewByte()
+	}
+	return c
+}
+
+// Define binds the input bytes to PreStateRoot || BlockHash and the output bytes to PostStateRoot,
+// so CircuitFunction's InputHash/OutputHash commit to these variables, then asserts that BlockHash
+// is the keccak of HeaderBytes, that every MPT proof in Witness.Proofs verifies against
+// PreStateRoot, and that applying Witness.Writes to PreStateRoot yields PostStateRoot.
+func (c *Circuit) Define(baseApi frontend.API) error {
+	api := newAPI(baseApi)
+
+	c.inputBytes = append(append([]vars.Byte{}, c.PreStateRoot[:]...), c.BlockHash[:]...)
+	c.outputBytes = append([]vars.Byte{}, c.PostStateRoot[:]...)
+
+	api.AssertKeccak(c.BlockHash, c.HeaderBytes)
+
+	for _, proof := range c.Witness.Proofs {
+		// The claimed value for each pre-state slot travels with the proof itself; callers that
+		// need to reference it (e.g. to feed it into the rest of a block's re-execution) should
+		// read it back off the leaf node, proof.Nodes[len(proof.Nodes)-1].Value.
+		leaf := proof.Nodes[len(proof.Nodes)-1]
+		api.VerifyProof(c.PreStateRoot, leaf.Value, proof)
+	}
+
+	api.ApplyWrites(c.PreStateRoot, c.Witness.Writes, c.PostStateRoot)
+	return nil
+}
+
+// newAPI builds a stateless.API over baseApi, mirroring how (*succinct.CircuitFunction).Define
+// builds a builder.API over the same frontend.API it hands to the wrapped Circuit.
+func newAPI(baseApi frontend.API) *API {
+	return NewAPI(builder.NewAPI(baseApi))
+}
+
+// SetWitness assigns PreStateRoot and BlockHash from inputBytes (preStateRoot || blockHash).
+// PostStateRoot and Witness (the MPT proofs and writes) are expected to already be set directly
+// on the Circuit before this is called (e.g. by the caller of CircuitFunction.Prove), since they
+// come from the result of off-circuit block execution rather than the onchain input bytes.
+func (c *Circuit) SetWitness(inputBytes []byte) {
+	if len(inputBytes) != 64 {
+		panic("stateless.Circuit input bytes must be exactly preStateRoot || blockHash (64 bytes)")
+	}
+	vars.SetBytes(&c.inputBytes, inputBytes)
+	vars.SetBytes(&c.outputBytes, vars.GetValuesUnsafe(c.PostStateRoot[:]))
+
+	for i := 0; i < 32; i++ {
+		c.PreStateRoot[i].Set(inputBytes[i])
+		c.BlockHash[i].Set(inputBytes[32+i])
+	}
+}
+
+func (c *Circuit) GetInputBytes() *[]vars.Byte {
+	return &c.inputBytes
+}
+
+func (c *Circuit) GetOutputBytes() *[]vars.Byte {
+	return &c.outputBytes
+}
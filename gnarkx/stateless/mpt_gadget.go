@@ -0,0 +1,206 @@
+package stateless
+
+import (
+	"github.com/succinctlabs/sdk/gnarkx/builder"
+	"github.com/succinctlabs/sdk/gnarkx/hash/keccak"
+	"github.com/succinctlabs/sdk/gnarkx/vars"
+)
+
+// API is a wrapper around builder.API providing gadgets for verifying Ethereum MPT proofs and
+// state-root transitions against a stateless witness.
+type API struct {
+	api builder.API
+}
+
+// NewAPI creates a new stateless.API.
+func NewAPI(api *builder.API) *API {
+	return &API{api: *api}
+}
+
+// AssertKeccak asserts that keccak256(data) equals expected.
+func (a *API) AssertKeccak(expected [32]vars.Byte, data []vars.Byte) {
+	a.assertBytes32Equal(expected, keccak.Hash(a.api, data))
+}
+
+// VerifyProof asserts that proof resolves to value at proof.Key under root. It walks proof.Nodes
+// from the root down: at every step the node's keccak must equal the hash referenced by the
+// node before it (or root, for the first node), and the key's nibbles must be consumed exactly,
+// one at a time for a branch node's selected child and in a shared run for an extension/leaf
+// node's decoded hex-prefix path. The final node must be a leaf whose value equals value.
+func (a *API) VerifyProof(root [32]vars.Byte, value []vars.Byte, proof Proof) {
+	keyNibbles := toNibbles(a.api, proof.Key)
+
+	expectedHash := root
+	nibbleOffset := 0
+	for i, node := range proof.Nodes {
+		nodeHash := keccak.HashN(a.api, node.Raw, node.RawLen)
+		a.assertBytes32Equal(expectedHash, nodeHash)
+		a.assertNodeEncoding(node)
+
+		switch node.Kind {
+		case NodeBranch:
+			nibble := keyNibbles[nibbleOffset]
+			expectedHash = a.selectChild(node.Children, nibble)
+			nibbleOffset++
+		case NodeExtension:
+			a.assertNibblesMatch(keyNibbles[nibbleOffset:nibbleOffset+len(node.Path)], node.Path)
+			nibbleOffset += len(node.Path)
+			expectedHash = node.Children[0]
+		case NodeLeaf:
+			a.assertNibblesMatch(keyNibbles[nibbleOffset:nibbleOffset+len(node.Path)], node.Path)
+			nibbleOffset += len(node.Path)
+		}
+
+		if i == len(proof.Nodes)-1 {
+			if node.Kind != NodeLeaf {
+				panic("the last node of an MPT proof must be a leaf")
+			}
+			a.assertBytesEqual(node.Value, value)
+		}
+	}
+}
+
+// ApplyWrites asserts that postRoot is reachable from preRoot by applying writes in order: each
+// write's OldProof must authenticate its OldValue under the running root (preRoot, or the
+// previous write's resulting root), and the root is then updated by replacing that leaf's value
+// with NewValue and re-hashing every node on the path back to the root, using each write's
+// NewNodes. With no writes, postRoot must equal preRoot directly.
+func (a *API) ApplyWrites(preRoot [32]vars.Byte, writes []Write, postRoot [32]vars.Byte) {
+	root := preRoot
+	for _, w := range writes {
+		root = a.applyWrite(root, w)
+	}
+	a.assertBytes32Equal(root, postRoot)
+}
+
+// applyWrite authenticates w.OldValue under root via VerifyProof, then returns the root that
+// results from replacing that leaf's value with w.NewValue, by re-hashing w.NewNodes bottom-up
+// and asserting every node other than the one updated child per level is unchanged from
+// w.OldProof.Nodes.
+func (a *API) applyWrite(root [32]vars.Byte, w Write) [32]vars.Byte {
+	a.VerifyProof(root, w.OldValue, w.OldProof)
+
+	n := len(w.OldProof.Nodes)
+	if n == 0 {
+		panic("a write's proof must have at least one node")
+	}
+	if len(w.NewNodes) != n {
+		panic("NewNodes must have one entry per OldProof.Nodes entry")
+	}
+
+	keyNibbles := toNibbles(a.api, w.OldProof.Key)
+	nibbleOffset := 0
+	selectedNibble := make([]vars.Variable, n)
+	for i, node := range w.OldProof.Nodes {
+		if node.Kind != w.NewNodes[i].Kind {
+			panic("NewNodes[i] must have the same Kind as OldProof.Nodes[i]")
+		}
+		switch node.Kind {
+		case NodeBranch:
+			selectedNibble[i] = keyNibbles[nibbleOffset]
+			nibbleOffset++
+		case NodeExtension, NodeLeaf:
+			a.assertBytesEqual(w.NewNodes[i].Path, node.Path)
+			nibbleOffset += len(node.Path)
+		}
+		a.assertNodeEncoding(w.NewNodes[i])
+	}
+
+	last := w.NewNodes[n-1]
+	if last.Kind != NodeLeaf {
+		panic("the last node of a write's path must be a leaf")
+	}
+	a.assertBytesEqual(last.Value, w.NewValue)
+
+	childHash := keccak.HashN(a.api, last.Raw, last.RawLen)
+	for i := n - 2; i >= 0; i-- {
+		oldNode := w.OldProof.Nodes[i]
+		newNode := w.NewNodes[i]
+
+		switch oldNode.Kind {
+		case NodeBranch:
+			a.assertBytesEqual(newNode.Value, oldNode.Value)
+			a.assertBranchChildrenMatchExceptSelected(oldNode, newNode, selectedNibble[i], childHash)
+		case NodeExtension:
+			a.assertBytes32Equal(newNode.Children[0], childHash)
+		}
+
+		childHash = keccak.HashN(a.api, newNode.Raw, newNode.RawLen)
+	}
+
+	return childHash
+}
+
+// assertBranchChildrenMatchExceptSelected asserts that newNode has the same ChildPresent pattern
+// and Children as oldNode at every nibble except selected, where it must instead equal newChild.
+// A write may not change which branch slots are occupied (see Write's doc comment); since
+// ChildPresent is now a witnessed circuit variable rather than a Go bool, that's an in-circuit
+// assertion here instead of a Go-level panic.
+func (a *API) assertBranchChildrenMatchExceptSelected(oldNode, newNode Node, selected vars.Variable, newChild [32]vars.Byte) {
+	for n := 0; n < 16; n++ {
+		a.api.FrontendAPI().AssertIsEqual(oldNode.ChildPresent[n].Value, newNode.ChildPresent[n].Value)
+
+		isSelected := a.api.FrontendAPI().IsZero(a.api.FrontendAPI().Sub(selected.Value, n))
+		for i := 0; i < 32; i++ {
+			expected := a.api.FrontendAPI().Select(isSelected, newChild[i].Value, oldNode.Children[n][i].Value)
+			a.api.FrontendAPI().AssertIsEqual(newNode.Children[n][i].Value, expected)
+		}
+	}
+}
+
+// selectChild returns children[nibble], asserted in-circuit via a linear scan since nibble is a
+// circuit variable (it is derived from the witnessed key, not known at compile time).
+func (a *API) selectChild(children [16][32]vars.Byte, nibble vars.Variable) [32]vars.Byte {
+	var selected [32]vars.Byte
+	for i := range selected {
+		selected[i] = vars.NewByte()
+	}
+
+	for n := 0; n < 16; n++ {
+		isSelected := a.api.FrontendAPI().IsZero(a.api.FrontendAPI().Sub(nibble.Value, n))
+		for i := 0; i < 32; i++ {
+			selected[i].Value = a.api.FrontendAPI().Select(isSelected, children[n][i].Value, selected[i].Value)
+		}
+	}
+	return selected
+}
+
+// assertNibblesMatch asserts that a node's shared path, decoded from its hex-prefix encoding,
+// equals the corresponding run of the key's nibbles.
+func (a *API) assertNibblesMatch(keyRun []vars.Variable, path []vars.Byte) {
+	if len(keyRun) != len(path) {
+		panic("key does not have enough remaining nibbles for this node's path")
+	}
+	for i := range path {
+		a.api.FrontendAPI().AssertIsEqual(keyRun[i].Value, path[i].Value)
+	}
+}
+
+// assertBytesEqual asserts that two equal-length byte slices are equal, element by element.
+func (a *API) assertBytesEqual(x, y []vars.Byte) {
+	if len(x) != len(y) {
+		panic("byte slices must have the same length to compare")
+	}
+	for i := range x {
+		a.api.FrontendAPI().AssertIsEqual(x[i].Value, y[i].Value)
+	}
+}
+
+func (a *API) assertBytes32Equal(x, y [32]vars.Byte) {
+	for i := 0; i < 32; i++ {
+		a.api.FrontendAPI().AssertIsEqual(x[i].Value, y[i].Value)
+	}
+}
+
+// toNibbles splits bytes into big-endian nibbles (high nibble first), as a circuit variable per
+// nibble so VerifyProof can compare them against a branch node's witnessed selector.
+func toNibbles(api builder.API, bytes []vars.Byte) []vars.Variable {
+	nibbles := make([]vars.Variable, 0, len(bytes)*2)
+	for _, b := range bytes {
+		bits := api.FrontendAPI().ToBinary(b.Value, 8)
+		hi := api.FrontendAPI().FromBinary(bits[4:]...)
+		lo := api.FrontendAPI().FromBinary(bits[:4]...)
+		nibbles = append(nibbles, vars.Variable{Value: hi}, vars.Variable{Value: lo})
+	}
+	return nibbles
+}
@@ -0,0 +1,97 @@
+// Package stateless verifies Ethereum execution-layer "stateless witnesses" in-circuit: the
+// pre-state Merkle-Patricia-Trie (MPT) proof nodes, code chunks, and headers go-ethereum's
+// stateless-witness subsystem bundles with a block are enough to re-execute it without a full
+// archive node. This package doesn't re-execute the block; it proves that (1) every witnessed MPT
+// node hashes to the value its parent references, (2) each pre-state slot the circuit cares about
+// is provable against preStateRoot through those nodes, and (3) applying a claimed list of
+// (key, value) writes to preStateRoot yields postStateRoot. The actual EVM execution that produces
+// those writes happens off-chain; this circuit only checks its state-transition bookkeeping.
+package stateless
+
+import "github.com/succinctlabs/sdk/gnarkx/vars"
+
+// NodeKind identifies which of the three MPT node encodings a Node holds.
+type NodeKind int
+
+const (
+	// NodeBranch is a 17-item node: 16 nibble-indexed children plus an optional value.
+	NodeBranch NodeKind = iota
+	// NodeExtension is a 2-item node sharing a nibble path with a single child node.
+	NodeExtension
+	// NodeLeaf is a 2-item node sharing a nibble path with a terminal value.
+	NodeLeaf
+)
+
+// MaxBranchValueLen bounds a NodeBranch's own Value (distinct from a leaf's terminal value,
+// branch nodes rarely carry one at all): worked into MaxBranchRawLen so a branch node's Raw
+// buffer can be sized once, independent of any particular trie's actual branch shapes.
+const MaxBranchValueLen = 32
+
+// MaxBranchRawLen is the largest a NodeBranch's Raw encoding can be: every one of its 16 children
+// present (each a 32-byte hash, RLP string-encoded as 33 bytes) plus a MaxBranchValueLen-byte
+// value, plus the list header those contents need. Building every NodeBranch witness with Raw
+// padded out to this length (real bytes first, unconstrained padding after), and witnessing
+// RawLen/ChildPresent instead of baking them into the Go struct, is what lets a single compiled
+// circuit verify proofs against tries whose branch nodes have different occupancy, rather than
+// needing a fresh compile (and trusted setup) per trie shape.
+var MaxBranchRawLen = len(rlpListHeader(16*33+len(rlpStringHeader(MaxBranchValueLen))+MaxBranchValueLen)) +
+	16*33 + len(rlpStringHeader(MaxBranchValueLen)) + MaxBranchValueLen
+
+// Node is one RLP-encoded MPT trie node from a stateless witness, alongside the decoded fields
+// VerifyProof needs to walk it. Raw holds the exact RLP bytes the node's keccak is checked
+// against (for a NodeBranch, padded out to MaxBranchRawLen; RawLen says how much of it is real);
+// the other fields are redundant with Raw and assertNodeEncoding (in rlp.go) asserts they match
+// its decoding.
+type Node struct {
+	Kind NodeKind
+	Raw  []vars.Byte
+
+	// RawLen is the number of meaningful bytes at the start of Raw; VerifyProof/ApplyWrites hash
+	// only raw[:RawLen] (see keccak.HashN), not all of Raw. assertNodeEncoding asserts it equals
+	// this node's actual encoded length, rather than trusting it as a free witness value. A
+	// NodeExtension/NodeLeaf always has RawLen == len(Raw) (their Path length is still Go-fixed,
+	// unlike a branch's occupancy); only NodeBranch actually uses a Raw longer than its real
+	// encoding.
+	RawLen vars.Variable
+
+	// Children holds, for a NodeBranch, the 16 nibble-indexed child hashes (indices with no child
+	// present may hold anything, since ChildPresent — not the Go value of this field — decides
+	// whether a slot's encoding is asserted); for a NodeExtension, the single child hash in
+	// Children[0] (Children[1:] are unused). Unused for NodeLeaf.
+	Children [16][32]vars.Byte
+
+	// ChildPresent[n] is a witnessed 0/1 circuit variable recording whether a NodeBranch has a
+	// child at nibble n; an absent child is RLP-encoded as the empty string, and Children[n] is
+	// ignored. Selected in-circuit the same way selectChild picks a child by witnessed nibble,
+	// rather than branching in Go on a concrete value, so the same compiled constraints handle any
+	// occupancy pattern. Unused for NodeExtension/NodeLeaf.
+	ChildPresent [16]vars.Variable
+
+	// Path holds the shared nibble path of a NodeExtension or NodeLeaf, decoded from the node's
+	// hex-prefix encoding.
+	Path []vars.Byte
+
+	// Value holds the branch's own value (if any) or a leaf's terminal value.
+	Value []vars.Byte
+}
+
+// Proof is the sequence of Nodes from a trie's root down to (and including) the node containing
+// key's value, as bundled in a stateless witness.
+type Proof struct {
+	Key   []vars.Byte
+	Nodes []Node
+}
+
+// Write is a single state mutation replacing OldValue with NewValue at Key, on top of the trie
+// root in force when it is applied. OldProof authenticates OldValue under that root; NewNodes is
+// the same path of nodes after replacing the leaf's value with NewValue (same Kind/Path/sibling
+// Children as OldProof.Nodes at every level, with only the touched leaf value and the hashes on
+// the path to it differing), from which ApplyWrites derives the post-write root. This package only
+// supports writes to an existing key (updating its value in place); growing or shrinking the trie
+// structure (inserting a brand-new key, deleting one) isn't handled.
+type Write struct {
+	OldValue []vars.Byte
+	NewValue []vars.Byte
+	OldProof Proof
+	NewNodes []Node
+}
@@ -0,0 +1,286 @@
+package stateless
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/succinctlabs/sdk/gnarkx/builder"
+	"github.com/succinctlabs/sdk/gnarkx/vars"
+)
+
+// This file asserts that a Node's decoded fields (Children/Path/Value) are actually the RLP
+// decoding of its Raw bytes, rather than free witness values VerifyProof merely trusts.
+//
+// For a NodeExtension/NodeLeaf, len(Raw) and len(Path) are still fixed when the witness is built,
+// so the RLP header bytes an encoding must start with are computable as Go constants; only the
+// header/content byte *values* inside Raw are circuit variables asserted against those constants
+// and against Children/Path/Value.
+//
+// A NodeBranch is different: which of its 16 children are present is a witnessed circuit variable
+// (ChildPresent), not a Go bool, so the header bytes and every content offset depend on a value
+// that isn't known until the witness is solved. assertBranchEncoding therefore reads/writes Raw
+// (padded out to MaxBranchRawLen) at circuit-variable offsets, selecting between each slot's two
+// possible encodings with Select instead of a Go if/else, so the constraints assertBranchEncoding
+// emits are the same regardless of occupancy — letting one compiled circuit verify branch nodes
+// of any shape.
+//
+// Supported encodings cover short and long RLP string/list headers of any static length, and the
+// standard hex-prefix nibble-path encoding. An RLP string whose content is exactly one byte is not
+// supported, since whether that encodes with or without a length prefix depends on the byte's
+// value (< 0x80) rather than its length, which would make the expected Raw length itself
+// witness-dependent. rlpStringHeader panics on such a field: a one-byte Value, or an
+// extension/leaf Path of fewer than two nibbles (whose hex-prefix encoding is a single byte).
+
+// assertNodeEncoding asserts that node.Raw is the canonical RLP encoding of node's Kind, Children
+// (and, for a branch, ChildPresent), Path, and Value.
+func (a *API) assertNodeEncoding(node Node) {
+	switch node.Kind {
+	case NodeBranch:
+		assertBranchEncoding(a.api, node)
+	case NodeExtension:
+		assertExtensionOrLeafEncoding(a.api, node, false)
+	case NodeLeaf:
+		assertExtensionOrLeafEncoding(a.api, node, true)
+	default:
+		panic("unknown NodeKind")
+	}
+}
+
+func assertBranchEncoding(api builder.API, node Node) {
+	if len(node.ChildPresent) != 16 {
+		panic("a branch node needs a 16-entry ChildPresent")
+	}
+	if len(node.Raw) != MaxBranchRawLen {
+		panic(fmt.Sprintf("a branch node's Raw must be padded to MaxBranchRawLen (%d), got %d",
+			MaxBranchRawLen, len(node.Raw)))
+	}
+
+	fapi := api.FrontendAPI()
+
+	payloadLen := frontend.Variable(len(rlpStringHeader(len(node.Value))) + len(node.Value))
+	for i := 0; i < 16; i++ {
+		assertBit(fapi, node.ChildPresent[i].Value)
+		slotLen := fapi.Select(node.ChildPresent[i].Value, len(rlpStringHeader(32))+32, 1)
+		payloadLen = fapi.Add(payloadLen, slotLen)
+	}
+
+	offset := assertVariableListHeader(fapi, node.Raw, payloadLen)
+	for i := 0; i < 16; i++ {
+		offset = assertBranchChildSlot(fapi, node.Raw, offset, node.ChildPresent[i].Value, node.Children[i][:])
+	}
+	offset = assertRLPStringVarsAt(fapi, node.Raw, offset, node.Value)
+
+	fapi.AssertIsEqual(node.RawLen.Value, offset)
+}
+
+// assertBranchChildSlot asserts that raw, starting at offset, holds either present's RLP-string
+// encoding of child (when present == 1) or the single byte 0x80 (when present == 0) — the same
+// assertion either way, so it doesn't matter that present is a witnessed variable rather than a Go
+// bool known when this is called. Returns the offset just past this slot.
+func assertBranchChildSlot(fapi frontend.API, raw []vars.Byte, offset, present frontend.Variable, child []vars.Byte) frontend.Variable {
+	header := rlpStringHeader(32)
+	assertByteAt(fapi, raw, offset, fapi.Select(present, int(header[0]), 0x80))
+	for i, b := range child {
+		// Only constrained when present == 1: rawByteAt(..., offset+1+i) is a tautological
+		// self-reference when present == 0, since child's bytes don't exist in the real encoding.
+		pos := fapi.Add(offset, 1+i)
+		fapi.AssertIsEqual(rawByteAt(fapi, raw, pos), fapi.Select(present, b.Value, rawByteAt(fapi, raw, pos)))
+	}
+	return fapi.Add(offset, fapi.Select(present, len(header)+32, 1))
+}
+
+// assertRLPStringVarsAt is assertRLPStringVars' variable-offset counterpart: offset is a circuit
+// variable, since a NodeBranch's content no longer starts at a Go-known position.
+func assertRLPStringVarsAt(fapi frontend.API, raw []vars.Byte, offset frontend.Variable, content []vars.Byte) frontend.Variable {
+	header := rlpStringHeader(len(content))
+	for i, b := range header {
+		assertByteAt(fapi, raw, fapi.Add(offset, i), int(b))
+	}
+	for i, b := range content {
+		assertByteAt(fapi, raw, fapi.Add(offset, len(header)+i), b.Value)
+	}
+	return fapi.Add(offset, len(header)+len(content))
+}
+
+// assertBit asserts that v is 0 or 1.
+func assertBit(fapi frontend.API, v frontend.Variable) {
+	fapi.AssertIsEqual(fapi.Mul(v, fapi.Sub(v, 1)), 0)
+}
+
+// rawByteAt returns raw[offset], offset a circuit variable, via a linear scan over every position
+// — the same pattern selectChild (in mpt_gadget.go) already uses to pick a branch child by a
+// witnessed nibble, generalized here to pick a raw byte by a witnessed offset.
+func rawByteAt(fapi frontend.API, raw []vars.Byte, offset frontend.Variable) frontend.Variable {
+	result := frontend.Variable(0)
+	for i, b := range raw {
+		isHere := fapi.IsZero(fapi.Sub(offset, i))
+		result = fapi.Select(isHere, b.Value, result)
+	}
+	return result
+}
+
+// assertByteAt asserts raw[offset] == value.
+func assertByteAt(fapi frontend.API, raw []vars.Byte, offset frontend.Variable, value frontend.Variable) {
+	fapi.AssertIsEqual(rawByteAt(fapi, raw, offset), value)
+}
+
+// assertVariableListHeader asserts that raw begins with the canonical RLP list header for a
+// payload of payloadLen bytes — short, medium (one length byte), or long (two length bytes) form
+// — and returns the header's length, i.e. the offset its payload starts at. payloadLen is a
+// circuit variable (it depends on ChildPresent), so which form applies isn't known until the
+// witness is solved either.
+func assertVariableListHeader(fapi frontend.API, raw []vars.Byte, payloadLen frontend.Variable) frontend.Variable {
+	isShort := isLE(fapi, payloadLen, 55)
+	isMedium := fapi.Mul(fapi.Sub(1, isShort), isLE(fapi, payloadLen, 255))
+	isLong := fapi.Sub(1, fapi.Add(isShort, isMedium))
+
+	// MaxBranchRawLen bounds payloadLen well under 65536, so a long-form header always needs
+	// exactly 2 length bytes (never needs a 3rd).
+	hi, lo := splitUint16(fapi, payloadLen, MaxBranchRawLen/256+1)
+
+	shortHeader := fapi.Add(0xc0, payloadLen)
+	header0 := fapi.Select(isShort, shortHeader, fapi.Select(isMedium, 0xf8, 0xf9))
+	assertByteAt(fapi, raw, 0, header0)
+
+	// byte 1 only matters for medium (the single length byte) or long (the length's high byte);
+	// for short form this is a tautological self-reference (no real constraint).
+	byte1 := fapi.Select(isShort, rawByteAt(fapi, raw, 1), fapi.Select(isMedium, payloadLen, hi))
+	assertByteAt(fapi, raw, 1, byte1)
+
+	// byte 2 only matters for long form (the length's low byte); short/medium leave it
+	// unconstrained via the same tautological self-reference trick.
+	isShortOrMedium := fapi.Add(isShort, isMedium)
+	byte2 := fapi.Select(isShortOrMedium, rawByteAt(fapi, raw, 2), lo)
+	assertByteAt(fapi, raw, 2, byte2)
+
+	return fapi.Add(fapi.Mul(isShort, 1), fapi.Add(fapi.Mul(isMedium, 2), fapi.Mul(isLong, 3)))
+}
+
+// isLE asserts nothing and returns 1 if a <= b, 0 otherwise.
+func isLE(fapi frontend.API, a, b frontend.Variable) frontend.Variable {
+	cmp := fapi.Cmp(a, b) // -1, 0, or 1
+	return fapi.Sub(1, fapi.IsZero(fapi.Sub(cmp, 1)))
+}
+
+// splitUint16 returns hi, lo such that v == hi*256 + lo and 0 <= lo <= 255, for v known to be at
+// most maxHi*256+255. It scans the (small, statically bounded) candidate values of hi rather than
+// computing a division, since v is itself built from a handful of Select'd terms rather than an
+// arbitrary witness value.
+func splitUint16(fapi frontend.API, v frontend.Variable, maxHi int) (hi, lo frontend.Variable) {
+	hi = frontend.Variable(0)
+	lo = v
+	for h := 1; h <= maxHi; h++ {
+		candidateLo := fapi.Sub(v, h*256)
+		inRange := fapi.Mul(isLE(fapi, 0, candidateLo), isLE(fapi, candidateLo, 255))
+		hi = fapi.Select(inRange, h, hi)
+		lo = fapi.Select(inRange, candidateLo, lo)
+	}
+	return hi, lo
+}
+
+func assertExtensionOrLeafEncoding(api builder.API, node Node, isLeaf bool) {
+	encodedPath := hexPrefixEncode(api, node.Path, isLeaf)
+
+	var item2Content []vars.Byte
+	if isLeaf {
+		item2Content = node.Value
+	} else {
+		item2Content = node.Children[0][:]
+	}
+
+	payloadLen := len(rlpStringHeader(len(encodedPath))) + len(encodedPath) +
+		len(rlpStringHeader(len(item2Content))) + len(item2Content)
+
+	offset := assertConstantBytes(api, node.Raw, 0, rlpListHeader(payloadLen))
+	offset += assertRLPStringVars(api, node.Raw, offset, encodedPath)
+	offset += assertRLPStringVars(api, node.Raw, offset, item2Content)
+
+	if offset != len(node.Raw) {
+		panic(fmt.Sprintf("extension/leaf node.Raw has %d unaccounted-for trailing bytes", len(node.Raw)-offset))
+	}
+	api.FrontendAPI().AssertIsEqual(node.RawLen.Value, offset)
+}
+
+// hexPrefixEncode returns the compact ("hex-prefix") encoding of path's nibbles, per the standard
+// Ethereum MPT rule: the first byte carries a leaf/extension flag and (for an odd-length path) the
+// first nibble, and every remaining pair of nibbles packs into one byte.
+func hexPrefixEncode(api builder.API, path []vars.Byte, isLeaf bool) []vars.Byte {
+	n := len(path)
+	terminatorFlag := 0
+	if isLeaf {
+		terminatorFlag = 1
+	}
+	oddFlag := n % 2
+	flagByte := terminatorFlag<<5 | oddFlag<<4
+
+	encoded := make([]vars.Byte, n/2+1)
+
+	start := 0
+	if oddFlag == 1 {
+		b := vars.NewByte()
+		b.Value = api.FrontendAPI().Add(flagByte, path[0].Value)
+		encoded[0] = b
+		start = 1
+	} else {
+		b := vars.NewByte()
+		b.Value = api.FrontendAPI().Add(flagByte, 0)
+		encoded[0] = b
+	}
+
+	for i := start; i < n; i += 2 {
+		b := vars.NewByte()
+		b.Value = api.FrontendAPI().Add(api.FrontendAPI().Mul(path[i].Value, 16), path[i+1].Value)
+		encoded[1+(i-start)/2] = b
+	}
+	return encoded
+}
+
+// assertRLPStringVars asserts that raw[offset:] begins with the canonical RLP string encoding of
+// content, and returns the number of bytes that encoding occupies.
+func assertRLPStringVars(api builder.API, raw []vars.Byte, offset int, content []vars.Byte) int {
+	header := rlpStringHeader(len(content))
+	n := assertConstantBytes(api, raw, offset, header)
+	for i, b := range content {
+		api.FrontendAPI().AssertIsEqual(raw[offset+n+i].Value, b.Value)
+	}
+	return n + len(content)
+}
+
+// assertConstantBytes asserts that raw[offset:offset+len(constant)] equals constant, and returns
+// len(constant).
+func assertConstantBytes(api builder.API, raw []vars.Byte, offset int, constant []byte) int {
+	for i, b := range constant {
+		api.FrontendAPI().AssertIsEqual(raw[offset+i].Value, int(b))
+	}
+	return len(constant)
+}
+
+// rlpStringHeader returns the canonical RLP header for a string of n bytes.
+func rlpStringHeader(n int) []byte {
+	if n == 1 {
+		panic("rlpStringHeader: unsupported content length 1 (a single byte <0x80 encodes without " +
+			"a length prefix, which this gadget does not special-case)")
+	}
+	return rlpHeader(n, 0x80, 0xb7)
+}
+
+// rlpListHeader returns the canonical RLP header for a list whose payload is n bytes.
+func rlpListHeader(n int) []byte {
+	return rlpHeader(n, 0xc0, 0xf7)
+}
+
+func rlpHeader(n int, shortBase, longBase byte) []byte {
+	if n <= 55 {
+		return []byte{shortBase + byte(n)}
+	}
+
+	var lenBytes []byte
+	for n > 0 {
+		lenBytes = append([]byte{byte(n & 0xff)}, lenBytes...)
+		n >>= 8
+	}
+	header := make([]byte, 1+len(lenBytes))
+	header[0] = longBase + byte(len(lenBytes))
+	copy(header[1:], lenBytes)
+	return header
+}
@@ -15,9 +15,7 @@ import (
 	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/succinctlabs/sdk/gnarkx/builder"
-	"github.com/succinctlabs/sdk/gnarkx/hash/sha256"
 	"github.com/succinctlabs/sdk/gnarkx/types"
-	"github.com/succinctlabs/sdk/gnarkx/utils/sha256utils"
 	"github.com/succinctlabs/sdk/gnarkx/vars"
 )
 
@@ -31,6 +29,10 @@ type CircuitFunction struct {
 
 	// The circuit definies the computation of the function.
 	Circuit Circuit
+
+	// The commitment scheme used to compute InputHash and OutputHash. Defaults to
+	// Sha256Truncated253, which is what the deployed FunctionVerifier.sol expects.
+	Commitment Commitment
 }
 
 // The interface a circuit interacting with the Succinct Hub must implement. These methods are used
@@ -43,19 +45,29 @@ type Circuit interface {
 	GetOutputBytes() *[]vars.Byte
 }
 
-// Creates a new circuit function based on a circuit that implements the Circuit interface.
+// Creates a new circuit function based on a circuit that implements the Circuit interface. The
+// input and output hashes are committed with Sha256Truncated253, matching the deployed
+// FunctionVerifier.sol. Use NewCircuitFunctionWithCommitment to opt into a different commitment.
 func NewCircuitFunction(c Circuit) CircuitFunction {
+	return NewCircuitFunctionWithCommitment(c, Sha256Truncated253{})
+}
+
+// Creates a new circuit function that commits to its input and output hashes with commit instead
+// of the default Sha256Truncated253. The exported FunctionVerifier.sol must be generated with a
+// matching commitment, since the onchain verifier needs to recompute the same hashes.
+func NewCircuitFunctionWithCommitment(c Circuit, commit Commitment) CircuitFunction {
 	function := CircuitFunction{}
 	function.InputHash = vars.NewVariable()
 	function.OutputHash = vars.NewVariable()
 	function.Circuit = c
+	function.Commitment = commit
 	return function
 }
 
 // Generate and set witnesses for the circuit function. In particular, this function will set the
 // input hash and output hash variables (which will be public values). Recall that all functions
 // have the form f(inputs, witness) = outputs. Both inputsHash and outputsHash are h(inputs) and
-// h(outputs) respectively, where h is a hash function.
+// h(outputs) respectively, where h is the function's Commitment.
 func (f *CircuitFunction) SetWitness(inputBytes []byte) {
 	// Set the input bytes.
 	vars.SetBytes(f.Circuit.GetInputBytes(), inputBytes)
@@ -63,22 +75,22 @@ func (f *CircuitFunction) SetWitness(inputBytes []byte) {
 	// Assign the circuit.
 	f.Circuit.SetWitness(inputBytes)
 
-	// Set inputHash = sha256(inputBytes) && ((1 << 253) - 1).
-	inputHash := sha256utils.HashAndTruncate(inputBytes, 253)
+	// Set inputHash = commit(inputBytes).
+	inputHash := f.Commitment.Host(inputBytes)
 	fmt.Println("inputHash", hex.EncodeToString(inputHash.Bytes()))
 	f.InputHash.Set(inputHash)
 
-	// Set outputHash = sha256(outputBytes) && ((1 << 253) - 1).
+	// Set outputHash = commit(outputBytes).
 	outputBytes := f.Circuit.GetOutputBytes()
 	outputBytesValues := vars.GetValuesUnsafe(*outputBytes)
 	fmt.Println("outputBytes", hex.EncodeToString(outputBytesValues))
-	outputHash := sha256utils.HashAndTruncate(outputBytesValues, 253)
+	outputHash := f.Commitment.Host(outputBytesValues)
 	fmt.Println("outputHash", hex.EncodeToString(outputHash.Bytes()))
 	f.OutputHash.Set(outputHash)
 }
 
 // Define the circuit. All circuit functions automatically constraint h(inputBytes) == inputHash
-// and h(outputBytes) == outputHash.
+// and h(outputBytes) == outputHash, where h is the function's Commitment.
 func (f *CircuitFunction) Define(baseApi frontend.API) error {
 	// Define the circuit using the Gnark standard API. Ideally, we would pass in builder.API
 	// but we can't becaues this is handled by Gnark internally.
@@ -86,16 +98,23 @@ func (f *CircuitFunction) Define(baseApi frontend.API) error {
 
 	// Automatically handle the input and output hashes and assert that they must be consistent.
 	api := builder.NewAPI(baseApi)
-	inputHash := sha256.HashAndTruncate(*api, *f.Circuit.GetInputBytes(), 253)
-	outputHash := sha256.HashAndTruncate(*api, *f.Circuit.GetOutputBytes(), 253)
+	inputHash := f.Commitment.InCircuit(api, *f.Circuit.GetInputBytes())
+	outputHash := f.Commitment.InCircuit(api, *f.Circuit.GetOutputBytes())
 	api.AssertIsEqual(f.InputHash, inputHash)
 	api.AssertIsEqual(f.OutputHash, outputHash)
 	return nil
 }
 
+// Compile compiles the circuit's r1cs without running a Groth16 Setup. Use this together with
+// the mpcsetup package's InitPhase2 to get an r1cs for a real multi-party ceremony, instead of
+// Build's single-party (toxic-waste) trusted setup.
+func (circuit *CircuitFunction) Compile() (constraint.ConstraintSystem, error) {
+	return frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+}
+
 // Build the circuit and serialize the r1cs, proving key, and verifying key to files.
 func (circuit *CircuitFunction) Build() (*CircuitBuild, error) {
-	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+	r1cs, err := circuit.Compile()
 	if err != nil {
 		return nil, err
 	}
@@ -106,16 +125,30 @@ func (circuit *CircuitFunction) Build() (*CircuitBuild, error) {
 	}
 
 	return &CircuitBuild{
-		pk:   pk,
-		vk:   vk,
-		r1cs: r1cs,
+		pk:         pk,
+		vk:         vk,
+		r1cs:       r1cs,
+		commitment: circuit.Commitment,
 	}, nil
 }
 
 type CircuitBuild struct {
-	pk   groth16.ProvingKey
-	vk   groth16.VerifyingKey
-	r1cs constraint.ConstraintSystem
+	pk         groth16.ProvingKey
+	vk         groth16.VerifyingKey
+	r1cs       constraint.ConstraintSystem
+	commitment Commitment
+}
+
+// NewCircuitBuild wraps an r1cs, proving key, and verifying key obtained some other way than this
+// package's own (single-party) Build — e.g. a Phase-2 MPC ceremony's Finalize — so they can be
+// used with CircuitFunction.Prove and CircuitBuild.Export exactly like a normal build.
+func NewCircuitBuild(r1cs constraint.ConstraintSystem, pk groth16.ProvingKey, vk groth16.VerifyingKey, commitment Commitment) *CircuitBuild {
+	return &CircuitBuild{
+		pk:         pk,
+		vk:         vk,
+		r1cs:       r1cs,
+		commitment: commitment,
+	}
 }
 
 func (build *CircuitBuild) Export() {
@@ -178,7 +211,13 @@ func (build *CircuitBuild) Export() {
 	defer verifierFile.Close()
 
 	svk := &SuccinctVerifyingKey{VerifyingKey: build.vk}
-	err = svk.ExportIFunctionVerifierSolidity(verifierFile)
+	if _, ok := build.commitment.(Poseidon2BN254); ok {
+		// The Poseidon2 commitment needs a verifier that recomputes InputHash/OutputHash with the
+		// Poseidon2 BN254 precompile/library instead of SHA256.
+		err = svk.ExportIFunctionVerifierSolidityPoseidon2(verifierFile)
+	} else {
+		err = svk.ExportIFunctionVerifierSolidity(verifierFile)
+	}
 	if err != nil {
 		fmt.Println("Failed to export solidity verifier:", err)
 		return
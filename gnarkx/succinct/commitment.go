@@ -0,0 +1,51 @@
+package succinct
+
+import (
+	"math/big"
+
+	"github.com/succinctlabs/sdk/gnarkx/builder"
+	"github.com/succinctlabs/sdk/gnarkx/hash/poseidon2"
+	"github.com/succinctlabs/sdk/gnarkx/hash/sha256"
+	"github.com/succinctlabs/sdk/gnarkx/utils/sha256utils"
+	"github.com/succinctlabs/sdk/gnarkx/vars"
+)
+
+// Commitment computes the value CircuitFunction binds to InputHash and OutputHash, both
+// in-circuit and on the host. A CircuitFunction must use the same Commitment on both sides, or
+// the hashes constrained by Define will never match the witness SetWitness computed.
+type Commitment interface {
+	// InCircuit commits to bytes as a circuit variable.
+	InCircuit(api *builder.API, bytes []vars.Byte) vars.Variable
+
+	// Host commits to bytes outside of a circuit, producing the same value InCircuit would.
+	Host(bytes []byte) *big.Int
+}
+
+// Sha256Truncated253 commits with sha256(bytes) & ((1 << 253) - 1), truncated to fit in a single
+// BN254 field element. This is the default commitment, and the only one the deployed
+// FunctionVerifier.sol understands onchain today.
+type Sha256Truncated253 struct{}
+
+func (Sha256Truncated253) InCircuit(api *builder.API, bytes []vars.Byte) vars.Variable {
+	return sha256.HashAndTruncate(*api, bytes, 253)
+}
+
+func (Sha256Truncated253) Host(bytes []byte) *big.Int {
+	return sha256utils.HashAndTruncate(bytes, 253)
+}
+
+// Poseidon2BN254 commits with the Poseidon2 permutation over the BN254 scalar field. SHA256
+// dominates constraint count for functions with small input/output byte counts (several gadgets
+// in this repo, including the SSZ API, already pay for a SHA256 in-circuit separately), so
+// functions that don't need onchain SHA256 compatibility can use this commitment instead at a
+// fraction of the constraints. Pairing this commitment with a CircuitFunction requires deploying
+// the Poseidon2 variant of FunctionVerifier.sol.
+type Poseidon2BN254 struct{}
+
+func (Poseidon2BN254) InCircuit(api *builder.API, bytes []vars.Byte) vars.Variable {
+	return poseidon2.HashBytes(*api, bytes)
+}
+
+func (Poseidon2BN254) Host(bytes []byte) *big.Int {
+	return poseidon2.HashBytesHost(bytes)
+}
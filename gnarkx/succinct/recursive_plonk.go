@@ -0,0 +1,259 @@
+package succinct
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/sw_bn254"
+	recursive_plonk "github.com/consensys/gnark/std/recursion/plonk"
+	"github.com/succinctlabs/sdk/gnarkx/builder"
+	gnarksha256 "github.com/succinctlabs/sdk/gnarkx/hash/sha256"
+	"github.com/succinctlabs/sdk/gnarkx/vars"
+)
+
+// RecursivePlonkWrapper is a Circuit that verifies a PLONK proof of another CircuitFunction
+// in-circuit, so that it can itself be wrapped in an outer Groth16 proof via CircuitFunction.
+// This lets the onchain FunctionVerifier.sol keep exposing a single Groth16 verify() call while
+// the inner function is proved with PLONK (e.g. because the inner function was built once and
+// proved many times, where PLONK's universal setup avoids a per-circuit trusted setup).
+//
+// The outer InputHash committed by CircuitFunction is computed over CommitmentBytes, a sha256
+// digest of the inner verifying key followed by the inner public inputs (the wrapper has no output
+// bytes), so a verifier only needs to trust the outer Groth16 proof to also trust everything the
+// inner PLONK proof attests to.
+type RecursivePlonkWrapper struct {
+	// The inner PLONK verifying key, as a circuit witness.
+	InnerVk recursive_plonk.VerifyingKey[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine]
+
+	// The inner PLONK proof, as a circuit witness.
+	InnerProof recursive_plonk.Proof[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine]
+
+	// The inner public witness, i.e. the inner circuit's InputHash and OutputHash.
+	InnerPublicInputs recursive_plonk.Witness[sw_bn254.ScalarField]
+
+	inputBytes  []vars.Byte
+	outputBytes []vars.Byte
+}
+
+// NewRecursivePlonkWrapper builds a Circuit that, when compiled and proved through
+// CircuitFunction, recursively verifies innerProof against innerVk and innerPublicInputs using
+// gnark's in-circuit PLONK verifier. innerVk, innerProof, and innerPublicInputs come from
+// building and proving some other CircuitFunction with the PLONK backend.
+func NewRecursivePlonkWrapper(
+	innerVk plonk.VerifyingKey,
+	innerProof plonk.Proof,
+	innerPublicInputs witness.Witness,
+) (*RecursivePlonkWrapper, error) {
+	vk, err := recursive_plonk.ValueOfVerifyingKey[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](innerVk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert inner verifying key: %w", err)
+	}
+
+	proof, err := recursive_plonk.ValueOfProof[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](innerProof)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert inner proof: %w", err)
+	}
+
+	publicWitness, err := recursive_plonk.ValueOfWitness[sw_bn254.ScalarField](innerPublicInputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert inner public witness: %w", err)
+	}
+
+	inputBytes := make([]vars.Byte, 32)
+	for i := range inputBytes {
+		inputBytes[i] = vars.NewByte()
+	}
+
+	return &RecursivePlonkWrapper{
+		InnerVk:           vk,
+		InnerProof:        proof,
+		InnerPublicInputs: publicWitness,
+		inputBytes:        inputBytes,
+	}, nil
+}
+
+// CommitmentBytes returns sha256(flatten(InnerVk) || flatten(InnerPublicInputs)): the bytes
+// CircuitFunction's InputHash should commit to so that the outer Groth16 proof is bound to a
+// specific inner verifying key and inner public inputs, not just "some" inner proof that happens
+// to verify. Define's assertInputBytesCommitInnerState recomputes this exact digest in-circuit
+// from InnerVk/InnerPublicInputs' own wires and asserts it equals inputBytes, so the two can't
+// drift apart: this is the host side of that same computation, over the concrete values
+// InnerVk/InnerPublicInputs were built from.
+func (c *RecursivePlonkWrapper) CommitmentBytes() ([]byte, error) {
+	variables := append(flattenVariables(c.InnerVk), flattenVariables(c.InnerPublicInputs)...)
+
+	var packed []byte
+	for _, v := range variables {
+		packed = append(packed, bigEndianBytes(toBigInt(v))...)
+	}
+
+	digest := sha256.Sum256(packed)
+	return digest[:], nil
+}
+
+// variableType is frontend.Variable's reflect.Type, used by flattenValue to recognize a
+// frontend.Variable leaf regardless of where it's nested.
+var variableType = reflect.TypeOf((*frontend.Variable)(nil)).Elem()
+
+// flattenVariables walks v — typically one of gnark's generic recursion-verifier types, like
+// RecursivePlonkWrapper.InnerVk's VerifyingKey[FR, G1, G2] or InnerPublicInputs' Witness[FR] — and
+// returns every frontend.Variable leaf it contains, in struct-declaration order. Those generic
+// types are versioned by an external library and don't expose a stable flat field list, so walking
+// their shape generically (instead of hardcoding field names that could silently drift out of sync
+// with a gnark upgrade) is what CommitmentBytes and assertInputBytesCommitInnerState both hash to
+// bind InnerVk/InnerPublicInputs to inputBytes.
+func flattenVariables(v interface{}) []frontend.Variable {
+	var out []frontend.Variable
+	flattenValue(reflect.ValueOf(v), &out)
+	return out
+}
+
+func flattenValue(val reflect.Value, out *[]frontend.Variable) {
+	if !val.IsValid() {
+		return
+	}
+	if val.Type() == variableType {
+		*out = append(*out, val.Interface().(frontend.Variable))
+		return
+	}
+
+	switch val.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if !val.IsNil() {
+			flattenValue(val.Elem(), out)
+		}
+	case reflect.Struct:
+		t := val.Type()
+		for i := 0; i < val.NumField(); i++ {
+			if t.Field(i).PkgPath != "" { // unexported; not ours to read
+				continue
+			}
+			flattenValue(val.Field(i), out)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			flattenValue(val.Index(i), out)
+		}
+	}
+}
+
+// toBigInt extracts the numeric value a concrete (host-side, not yet compiled) frontend.Variable
+// wraps, covering the representations gnark/gnark-crypto commonly use for field elements.
+func toBigInt(v frontend.Variable) *big.Int {
+	switch t := v.(type) {
+	case *big.Int:
+		return new(big.Int).Set(t)
+	case big.Int:
+		return new(big.Int).Set(&t)
+	case int:
+		return big.NewInt(int64(t))
+	case int64:
+		return big.NewInt(t)
+	case uint64:
+		return new(big.Int).SetUint64(t)
+	case interface{ BigInt(res *big.Int) *big.Int }:
+		var res big.Int
+		t.BigInt(&res)
+		return &res
+	default:
+		panic(fmt.Sprintf("flattenVariables: unsupported concrete variable representation %T", v))
+	}
+}
+
+// bigEndianBytes is packBigEndianBytes' host-side counterpart: both must agree byte-for-byte for
+// CommitmentBytes' digest to match assertInputBytesCommitInnerState's in-circuit one.
+func bigEndianBytes(x *big.Int) []byte {
+	b := make([]byte, 32)
+	x.FillBytes(b)
+	return b
+}
+
+// packBigEndianBytes returns v's value as 32 big-endian bytes, matching bigEndianBytes.
+func packBigEndianBytes(api *builder.API, v frontend.Variable) []vars.Byte {
+	bits := api.FrontendAPI().ToBinary(v, 256)
+	out := make([]vars.Byte, 32)
+	for k := 0; k < 32; k++ {
+		start := (31 - k) * 8
+		b := vars.NewByte()
+		b.Value = api.FrontendAPI().FromBinary(bits[start : start+8]...)
+		out[k] = b
+	}
+	return out
+}
+
+// PlaceholderVerifyingKey allocates a verifying key of the right shape for ccs, to be used when
+// compiling a RecursivePlonkWrapper circuit without a concrete inner verifying key on hand.
+func PlaceholderVerifyingKey(ccs constraint.ConstraintSystem) recursive_plonk.VerifyingKey[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine] {
+	return recursive_plonk.PlaceholderVerifyingKey[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](ccs)
+}
+
+// PlaceholderProof allocates a proof of the right shape for ccs, to be used when compiling a
+// RecursivePlonkWrapper circuit without a concrete inner proof on hand.
+func PlaceholderProof(ccs constraint.ConstraintSystem) recursive_plonk.Proof[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine] {
+	return recursive_plonk.PlaceholderProof[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](ccs)
+}
+
+// Define instantiates gnark's generic in-circuit PLONK verifier, asserts that InnerProof verifies
+// against InnerVk and InnerPublicInputs, and asserts that inputBytes actually commits to
+// InnerVk/InnerPublicInputs (see assertInputBytesCommitInnerState), so the outer Groth16 proof
+// can't be paired with an inner vk/proof/public-input triple unrelated to its committed InputHash.
+func (c *RecursivePlonkWrapper) Define(api frontend.API) error {
+	verifier, err := recursive_plonk.NewVerifier[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](api)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate in-circuit plonk verifier: %w", err)
+	}
+
+	if err := verifier.AssertProof(c.InnerVk, c.InnerProof, c.InnerPublicInputs); err != nil {
+		return fmt.Errorf("failed to assert inner plonk proof: %w", err)
+	}
+
+	c.assertInputBytesCommitInnerState(api)
+	return nil
+}
+
+// assertInputBytesCommitInnerState asserts that c.inputBytes is exactly
+// sha256(flatten(InnerVk) || flatten(InnerPublicInputs)), computed over InnerVk/InnerPublicInputs'
+// own wires rather than trusted as a free witness value. CommitmentBytes computes the identical
+// digest on the host, from the same concrete InnerVk/InnerPublicInputs values, so a caller that
+// passes its result to CircuitFunction.Prove (as RecursivePlonkBuild.Prove does) always satisfies
+// this.
+func (c *RecursivePlonkWrapper) assertInputBytesCommitInnerState(api frontend.API) {
+	if len(c.inputBytes) != 32 {
+		panic("RecursivePlonkWrapper.inputBytes must be exactly the 32-byte CommitmentBytes() digest")
+	}
+
+	bAPI := builder.NewAPI(api)
+	variables := append(flattenVariables(c.InnerVk), flattenVariables(c.InnerPublicInputs)...)
+
+	var packed []vars.Byte
+	for _, v := range variables {
+		packed = append(packed, packBigEndianBytes(bAPI, v)...)
+	}
+	digest := gnarksha256.Hash(*bAPI, packed)
+
+	for i := 0; i < 32; i++ {
+		api.AssertIsEqual(c.inputBytes[i].Value, digest[i].Value)
+	}
+}
+
+// SetWitness just refreshes the committed input bytes; the inner vk/proof/public input witnesses
+// are assigned directly on the struct by NewRecursivePlonkWrapper. Callers must pass
+// CommitmentBytes() here (RecursivePlonkBuild.Prove does, through CircuitFunction.Prove), since
+// Define asserts that inputBytes is exactly that digest.
+func (c *RecursivePlonkWrapper) SetWitness(inputBytes []byte) {
+	vars.SetBytes(&c.inputBytes, inputBytes)
+}
+
+func (c *RecursivePlonkWrapper) GetInputBytes() *[]vars.Byte {
+	return &c.inputBytes
+}
+
+func (c *RecursivePlonkWrapper) GetOutputBytes() *[]vars.Byte {
+	return &c.outputBytes
+}
@@ -0,0 +1,108 @@
+package succinct_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test/unsafekzg"
+	"github.com/succinctlabs/sdk/gnarkx/succinct"
+)
+
+// trivialCircuit asserts A*B == C, a minimal inner circuit to recursively verify.
+type trivialCircuit struct {
+	A, B frontend.Variable
+	C    frontend.Variable `gnark:",public"`
+}
+
+func (c *trivialCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.A, c.B), c.C)
+	return nil
+}
+
+// TestRecursivePlonkWrapper builds a trivial inner PLONK proof, recursively verifies it through
+// an outer Groth16 proof, and checks the outer Solidity verifier exports without error.
+func TestRecursivePlonkWrapper(t *testing.T) {
+	var inner trivialCircuit
+	innerCcs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &inner)
+	if err != nil {
+		t.Fatalf("failed to compile inner circuit: %v", err)
+	}
+
+	srs, srsLagrange, err := unsafekzg.NewSRS(innerCcs)
+	if err != nil {
+		t.Fatalf("failed to generate inner kzg srs: %v", err)
+	}
+
+	innerPk, innerVk, err := plonk.Setup(innerCcs, srs, srsLagrange)
+	if err != nil {
+		t.Fatalf("failed to run inner plonk setup: %v", err)
+	}
+
+	assignment := &trivialCircuit{A: 3, B: 5, C: 15}
+	innerWitness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("failed to create inner witness: %v", err)
+	}
+
+	innerProof, err := plonk.Prove(innerCcs, innerPk, innerWitness)
+	if err != nil {
+		t.Fatalf("failed to create inner proof: %v", err)
+	}
+
+	innerPublicWitness, err := innerWitness.Public()
+	if err != nil {
+		t.Fatalf("failed to extract inner public witness: %v", err)
+	}
+	if err := plonk.Verify(innerProof, innerVk, innerPublicWitness); err != nil {
+		t.Fatalf("inner plonk proof failed to verify directly: %v", err)
+	}
+
+	wrapper, err := succinct.NewRecursivePlonkWrapper(innerVk, innerProof, innerPublicWitness)
+	if err != nil {
+		t.Fatalf("failed to construct recursive plonk wrapper: %v", err)
+	}
+
+	outer := succinct.NewCircuitFunction(wrapper)
+	build, err := outer.Build()
+	if err != nil {
+		t.Fatalf("failed to build outer circuit: %v", err)
+	}
+
+	inputBytes, err := wrapper.CommitmentBytes()
+	if err != nil {
+		t.Fatalf("failed to compute wrapper commitment bytes: %v", err)
+	}
+
+	outerProof, err := outer.Prove(inputBytes, build)
+	if err != nil {
+		t.Fatalf("failed to prove outer circuit: %v", err)
+	}
+	if outerProof == nil {
+		t.Fatal("expected a non-nil outer groth16 proof")
+	}
+
+	dir := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to chdir into temp dir: %v", err)
+	}
+	defer os.Chdir(cwd)
+
+	build.Export()
+
+	solidity, err := os.ReadFile("build/FunctionVerifier.sol")
+	if err != nil {
+		t.Fatalf("failed to read exported solidity verifier: %v", err)
+	}
+	if len(bytes.TrimSpace(solidity)) == 0 {
+		t.Fatal("expected a non-empty exported solidity verifier")
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/consensys/gnark/backend/plonk"
 	"github.com/consensys/gnark/logger"
+	"github.com/succinctlabs/sdk/gnarkx/mpcsetup"
 )
 
 func main() {
@@ -16,18 +17,41 @@ func main() {
 	verifyFlag := flag.Bool("verify", false, "verify a proof")
 	compileFlag := flag.Bool("compile", false, "Compile and save the universal verifier circuit")
 	contractFlag := flag.Bool("contract", true, "Generate solidity contract")
+	recursivePlonkFlag := flag.Bool("recursive-plonk", false, "Recursively verify a PLONK proof through a Groth16 outer proof")
+	innerVkPath := flag.String("inner-vk", "", "path to the inner PLONK verifying key")
+	innerProofPath := flag.String("inner-proof", "", "path to the inner PLONK proof")
+	innerWitnessPath := flag.String("inner-witness", "", "path to the inner PLONK public witness")
+	commitmentFlag := flag.String("commitment", "sha256", "commitment scheme for InputHash/OutputHash: sha256|poseidon2")
+
+	mpcCompileFlag := flag.Bool("mpc-compile", false, "compile the recursive plonk wrapper's r1cs without running a trusted setup, for use with -mpc-phase2")
+	mpcPhase1Flag := flag.Bool("mpc-phase1", false, "initialize or contribute to a phase 1 MPC transcript")
+	mpcPhase2Flag := flag.Bool("mpc-phase2", false, "initialize or contribute to a phase 2 MPC transcript")
+	mpcContributeFlag := flag.Bool("mpc-contribute", false, "contribute to the previous transcript, instead of initializing a new one")
+	mpcFinalizeFlag := flag.Bool("mpc-finalize", false, "finalize the ceremony into a proving key and verifying key")
+	mpcVerifyFlag := flag.Bool("mpc-verify", false, "audit every contribution in a ceremony directory")
+	mpcDir := flag.String("mpc-dir", "", "ceremony directory holding phase1.<n>.bin / phase2.<n>.bin contributions")
+	mpcPower := flag.Int("mpc-power", 0, "the phase 1 transcript supports circuits of up to 2^mpc-power constraints")
+	mpcR1CSPath := flag.String("mpc-r1cs", "", "path to the r1cs.bin of the circuit being set up")
 	flag.Parse()
 
 	log := logger.Logger()
 
-	if *circuitPath == "" {
-		log.Error().Msg("please specify a path to circuit dir (containing verifier_only_circuit_data and proof_with_public_inputs)")
-		os.Exit(1)
+	// -circuit is required by the -compile, -prove, and -verify flows; -data is needed a bit
+	// more broadly, by every flow that writes or reads files in the data directory. The
+	// recursive-plonk and mpc-phase1/phase2/contribute/verify flows take their inputs from their
+	// own flags instead and don't need either.
+	if *compileFlag || *proofFlag || *verifyFlag {
+		if *circuitPath == "" {
+			log.Error().Msg("please specify a path to circuit dir (containing verifier_only_circuit_data and proof_with_public_inputs)")
+			os.Exit(1)
+		}
 	}
 
-	if *dataPath == "" {
-		log.Error().Msg("please specify a path to data dir (where the compiled gnark circuit data will be)")
-		os.Exit(1)
+	if *compileFlag || *proofFlag || *verifyFlag || *mpcCompileFlag || *mpcFinalizeFlag {
+		if *dataPath == "" {
+			log.Error().Msg("please specify a path to data dir (where the compiled gnark circuit data will be)")
+			os.Exit(1)
+		}
 	}
 
 	log.Debug().Msg("Circuit path: " + *circuitPath)
@@ -109,4 +133,130 @@ func main() {
 		}
 		log.Info().Msg("Successfully verified proof")
 	}
+
+	if *recursivePlonkFlag {
+		if *innerVkPath == "" || *innerProofPath == "" || *innerWitnessPath == "" {
+			log.Error().Msg("please specify -inner-vk, -inner-proof, and -inner-witness")
+			os.Exit(1)
+		}
+
+		log.Info().Msg("loading inner plonk verifying key, proof, and public witness")
+		innerVk, innerProof, innerPublicInputs, err := LoadRecursivePlonkInputs(
+			*innerVkPath, *innerProofPath, *innerWitnessPath,
+		)
+		if err != nil {
+			log.Err(err).Msg("failed to load inner plonk proof")
+			os.Exit(1)
+		}
+
+		commitment, err := ParseCommitment(*commitmentFlag)
+		if err != nil {
+			log.Err(err).Msg("invalid -commitment flag")
+			os.Exit(1)
+		}
+
+		log.Info().Msg("building the recursive plonk wrapper circuit")
+		build, err := BuildRecursivePlonkWrapper(innerVk, innerProof, innerPublicInputs, commitment)
+		if err != nil {
+			log.Err(err).Msg("failed to build the recursive plonk wrapper circuit")
+			os.Exit(1)
+		}
+
+		if *contractFlag {
+			log.Info().Msg("exporting r1cs, groth16 keys, and solidity contract")
+			build.Export()
+		}
+
+		log.Info().Msg("proving the outer groth16 wrapper of the inner plonk proof")
+		_, err = build.Prove()
+		if err != nil {
+			log.Err(err).Msg("failed to prove the recursive plonk wrapper circuit")
+			os.Exit(1)
+		}
+		log.Info().Msg("Successfully proved recursive plonk proof through outer groth16 proof")
+	}
+
+	if *mpcCompileFlag {
+		if *innerVkPath == "" || *innerProofPath == "" || *innerWitnessPath == "" {
+			log.Error().Msg("please specify -inner-vk, -inner-proof, and -inner-witness")
+			os.Exit(1)
+		}
+
+		log.Info().Msg("loading inner plonk verifying key, proof, and public witness")
+		innerVk, innerProof, innerPublicInputs, err := LoadRecursivePlonkInputs(
+			*innerVkPath, *innerProofPath, *innerWitnessPath,
+		)
+		if err != nil {
+			log.Err(err).Msg("failed to load inner plonk proof")
+			os.Exit(1)
+		}
+
+		commitment, err := ParseCommitment(*commitmentFlag)
+		if err != nil {
+			log.Err(err).Msg("invalid -commitment flag")
+			os.Exit(1)
+		}
+
+		log.Info().Msg("compiling the recursive plonk wrapper r1cs without running a trusted setup")
+		r1cs, err := CompileRecursivePlonkWrapper(innerVk, innerProof, innerPublicInputs, commitment)
+		if err != nil {
+			log.Err(err).Msg("failed to compile the recursive plonk wrapper circuit")
+			os.Exit(1)
+		}
+
+		if err := WriteR1CS(*dataPath, r1cs); err != nil {
+			log.Err(err).Msg("failed to write r1cs")
+			os.Exit(1)
+		}
+		log.Info().Msg("Successfully compiled r1cs without running a trusted setup")
+	}
+
+	if *mpcPhase1Flag {
+		log.Info().Msg("running phase 1 MPC step")
+		if err := RunMPCPhase1(*mpcDir, *mpcPower, *mpcContributeFlag); err != nil {
+			log.Err(err).Msg("failed phase 1 MPC step")
+			os.Exit(1)
+		}
+		log.Info().Msg("Successfully wrote phase 1 contribution")
+	}
+
+	if *mpcPhase2Flag {
+		log.Info().Msg("running phase 2 MPC step")
+		if err := RunMPCPhase2(*mpcDir, *mpcR1CSPath, *mpcContributeFlag); err != nil {
+			log.Err(err).Msg("failed phase 2 MPC step")
+			os.Exit(1)
+		}
+		log.Info().Msg("Successfully wrote phase 2 contribution")
+	}
+
+	if *mpcFinalizeFlag {
+		log.Info().Msg("finalizing MPC ceremony")
+		if err := RunMPCFinalize(*mpcDir, *mpcR1CSPath, *dataPath); err != nil {
+			log.Err(err).Msg("failed to finalize MPC ceremony")
+			os.Exit(1)
+		}
+
+		if *contractFlag {
+			log.Info().Msg("generating solidity contract")
+			vk, err := LoadVerifierKey(*dataPath)
+			if err != nil {
+				log.Err(err).Msg("failed to load the verifying key")
+				os.Exit(1)
+			}
+			if err := ExportIFunctionVerifierSolidity(*dataPath, vk); err != nil {
+				log.Error().Msg("failed to generate solidity contract:" + err.Error())
+				os.Exit(1)
+			}
+		}
+		log.Info().Msg("Successfully finalized MPC ceremony")
+	}
+
+	if *mpcVerifyFlag {
+		log.Info().Msg("auditing MPC ceremony contributions")
+		if err := mpcsetup.VerifyContributions(*mpcDir); err != nil {
+			log.Err(err).Msg("ceremony audit failed")
+			os.Exit(1)
+		}
+		log.Info().Msg("Successfully audited all ceremony contributions")
+	}
 }
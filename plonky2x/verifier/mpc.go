@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/constraint"
+	"github.com/succinctlabs/sdk/gnarkx/mpcsetup"
+)
+
+// RunMPCPhase1 either initializes a fresh phase 1 transcript (power, no prior contribution in
+// dir) or contributes to the most recent one, writing the result as the next phase1.<n>.bin file.
+func RunMPCPhase1(dir string, power int, contribute bool) error {
+	paths, err := existingContributions(dir, mpcsetup.Phase1FileName)
+	if err != nil {
+		return err
+	}
+
+	var next *mpcsetup.Phase1
+	if !contribute {
+		if len(paths) != 0 {
+			return fmt.Errorf("%s already has a phase 1 transcript; pass -mpc-contribute to extend it", dir)
+		}
+		next, err = mpcsetup.InitPhase1(power)
+		if err != nil {
+			return fmt.Errorf("failed to initialize phase 1: %w", err)
+		}
+	} else {
+		if len(paths) == 0 {
+			return fmt.Errorf("%s has no phase 1 transcript to contribute to", dir)
+		}
+		prev, err := mpcsetup.ReadPhase1(paths[len(paths)-1])
+		if err != nil {
+			return fmt.Errorf("failed to read previous phase 1 contribution: %w", err)
+		}
+		next, err = mpcsetup.ContributePhase1(prev)
+		if err != nil {
+			return fmt.Errorf("failed to contribute to phase 1: %w", err)
+		}
+	}
+
+	return mpcsetup.WritePhase1(contributionPath(dir, mpcsetup.Phase1FileName, len(paths)), next)
+}
+
+// RunMPCPhase2 either initializes a phase 2 transcript from the final phase 1 contribution and
+// r1cs, or contributes to the most recent phase 2 contribution.
+func RunMPCPhase2(dir, r1csPath string, contribute bool) error {
+	paths, err := existingContributions(dir, mpcsetup.Phase2FileName)
+	if err != nil {
+		return err
+	}
+
+	var next *mpcsetup.Phase2
+	if !contribute {
+		if len(paths) != 0 {
+			return fmt.Errorf("%s already has a phase 2 transcript; pass -mpc-contribute to extend it", dir)
+		}
+		phase1Paths, err := existingContributions(dir, mpcsetup.Phase1FileName)
+		if err != nil {
+			return err
+		}
+		if len(phase1Paths) == 0 {
+			return fmt.Errorf("%s has no phase 1 transcript to specialize", dir)
+		}
+		phase1, err := mpcsetup.ReadPhase1(phase1Paths[len(phase1Paths)-1])
+		if err != nil {
+			return fmt.Errorf("failed to read final phase 1 contribution: %w", err)
+		}
+		r1cs, err := readR1CS(r1csPath)
+		if err != nil {
+			return err
+		}
+		next, err = mpcsetup.InitPhase2(phase1, r1cs)
+		if err != nil {
+			return fmt.Errorf("failed to initialize phase 2: %w", err)
+		}
+	} else {
+		if len(paths) == 0 {
+			return fmt.Errorf("%s has no phase 2 transcript to contribute to", dir)
+		}
+		prev, err := mpcsetup.ReadPhase2(paths[len(paths)-1])
+		if err != nil {
+			return fmt.Errorf("failed to read previous phase 2 contribution: %w", err)
+		}
+		next, err = mpcsetup.ContributePhase2(prev)
+		if err != nil {
+			return fmt.Errorf("failed to contribute to phase 2: %w", err)
+		}
+	}
+
+	return mpcsetup.WritePhase2(contributionPath(dir, mpcsetup.Phase2FileName, len(paths)), next)
+}
+
+// RunMPCFinalize extracts the proving and verifying keys from the final phase 1/phase 2
+// contributions in dir, writing them to dataPath/pkey.bin and dataPath/vkey.bin, the same file
+// names (*CircuitBuild).Export uses under build/.
+func RunMPCFinalize(dir, r1csPath, dataPath string) error {
+	phase1Paths, err := existingContributions(dir, mpcsetup.Phase1FileName)
+	if err != nil {
+		return err
+	}
+	phase2Paths, err := existingContributions(dir, mpcsetup.Phase2FileName)
+	if err != nil {
+		return err
+	}
+	if len(phase1Paths) == 0 || len(phase2Paths) == 0 {
+		return fmt.Errorf("%s must have at least one phase 1 and one phase 2 contribution", dir)
+	}
+
+	phase1, err := mpcsetup.ReadPhase1(phase1Paths[len(phase1Paths)-1])
+	if err != nil {
+		return fmt.Errorf("failed to read final phase 1 contribution: %w", err)
+	}
+	phase2, err := mpcsetup.ReadPhase2(phase2Paths[len(phase2Paths)-1])
+	if err != nil {
+		return fmt.Errorf("failed to read final phase 2 contribution: %w", err)
+	}
+	r1cs, err := readR1CS(r1csPath)
+	if err != nil {
+		return err
+	}
+
+	pk, vk, err := mpcsetup.Finalize(phase1, phase2, r1cs)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dataPath, 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	pkFile, err := os.Create(filepath.Join(dataPath, "pkey.bin"))
+	if err != nil {
+		return fmt.Errorf("failed to create proving key file: %w", err)
+	}
+	defer pkFile.Close()
+	if _, err := pk.WriteTo(pkFile); err != nil {
+		return fmt.Errorf("failed to write proving key: %w", err)
+	}
+
+	vkFile, err := os.Create(filepath.Join(dataPath, "vkey.bin"))
+	if err != nil {
+		return fmt.Errorf("failed to create verifying key file: %w", err)
+	}
+	defer vkFile.Close()
+	if _, err := vk.WriteTo(vkFile); err != nil {
+		return fmt.Errorf("failed to write verifying key: %w", err)
+	}
+
+	return nil
+}
+
+// WriteR1CS writes r1cs to dataPath/r1cs.bin, the non-toxic counterpart to
+// (*CircuitFunction).Build's Setup: compile with (*CircuitFunction).Compile, write it here, then
+// hand the path to -mpc-phase2's -mpc-r1cs flag to start a real ceremony instead.
+func WriteR1CS(dataPath string, r1cs constraint.ConstraintSystem) error {
+	if err := os.MkdirAll(dataPath, 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(dataPath, "r1cs.bin"))
+	if err != nil {
+		return fmt.Errorf("failed to create r1cs file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := r1cs.WriteTo(f); err != nil {
+		return fmt.Errorf("failed to write r1cs: %w", err)
+	}
+	return nil
+}
+
+// readR1CS loads a compiled r1cs.bin, in the format (*CircuitBuild).Export writes.
+func readR1CS(path string) (constraint.ConstraintSystem, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open r1cs: %w", err)
+	}
+	defer f.Close()
+
+	r1cs := groth16.NewCS(ecc.BN254)
+	if _, err := r1cs.ReadFrom(f); err != nil {
+		return nil, fmt.Errorf("failed to read r1cs: %w", err)
+	}
+	return r1cs, nil
+}
+
+// existingContributions returns the "<prefix>.<n>.bin" files already present in dir, in
+// contribution order.
+func existingContributions(dir, prefix string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create ceremony directory: %w", err)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ceremony directory: %w", err)
+	}
+
+	var indices []int
+	for _, entry := range entries {
+		var index int
+		if _, err := fmt.Sscanf(entry.Name(), prefix+".%d.bin", &index); err == nil {
+			indices = append(indices, index)
+		}
+	}
+	sort.Ints(indices)
+
+	paths := make([]string, len(indices))
+	for i, index := range indices {
+		paths[i] = contributionPath(dir, prefix, index)
+	}
+	return paths, nil
+}
+
+// contributionPath returns the file path for the n-th contribution of the given prefix.
+func contributionPath(dir, prefix string, n int) string {
+	return filepath.Join(dir, fmt.Sprintf("%s.%d.bin", prefix, n))
+}
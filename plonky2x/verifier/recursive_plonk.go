@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/succinctlabs/sdk/gnarkx/succinct"
+	"github.com/succinctlabs/sdk/gnarkx/types"
+)
+
+// LoadRecursivePlonkInputs reads an inner PLONK verifying key, proof, and public witness from
+// disk, in the formats written by the -compile/-prove flags of this same binary.
+func LoadRecursivePlonkInputs(vkPath, proofPath, witnessPath string) (plonk.VerifyingKey, plonk.Proof, witness.Witness, error) {
+	vk := plonk.NewVerifyingKey(ecc.BN254)
+	vkFile, err := os.Open(vkPath)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to open inner verifying key: %w", err)
+	}
+	defer vkFile.Close()
+	if _, err := vk.ReadFrom(vkFile); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read inner verifying key: %w", err)
+	}
+
+	proof := plonk.NewProof(ecc.BN254)
+	proofFile, err := os.Open(proofPath)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to open inner proof: %w", err)
+	}
+	defer proofFile.Close()
+	if _, err := proof.ReadFrom(proofFile); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read inner proof: %w", err)
+	}
+
+	publicWitness, err := witness.New(ecc.BN254.ScalarField())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to allocate inner public witness: %w", err)
+	}
+	witnessFile, err := os.Open(witnessPath)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to open inner public witness: %w", err)
+	}
+	defer witnessFile.Close()
+	if _, err := publicWitness.ReadFrom(witnessFile); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read inner public witness: %w", err)
+	}
+
+	return vk, proof, publicWitness, nil
+}
+
+// RecursivePlonkBuild holds the compiled outer Groth16 circuit that recursively verifies a
+// single inner PLONK proof, along with the succinct.CircuitFunction wrapping it.
+type RecursivePlonkBuild struct {
+	build    *succinct.CircuitBuild
+	function succinct.CircuitFunction
+	circuit  *succinct.RecursivePlonkWrapper
+}
+
+// BuildRecursivePlonkWrapper compiles a succinct.CircuitFunction wrapping a
+// succinct.RecursivePlonkWrapper and runs the (single-party, test-only) Groth16 setup on it.
+func BuildRecursivePlonkWrapper(
+	innerVk plonk.VerifyingKey,
+	innerProof plonk.Proof,
+	innerPublicInputs witness.Witness,
+	commitment succinct.Commitment,
+) (*RecursivePlonkBuild, error) {
+	circuit, err := succinct.NewRecursivePlonkWrapper(innerVk, innerProof, innerPublicInputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct recursive plonk wrapper: %w", err)
+	}
+
+	function := succinct.NewCircuitFunctionWithCommitment(circuit, commitment)
+	build, err := function.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build recursive plonk wrapper circuit: %w", err)
+	}
+
+	return &RecursivePlonkBuild{build: build, function: function, circuit: circuit}, nil
+}
+
+// CompileRecursivePlonkWrapper compiles the recursive plonk wrapper circuit's r1cs without
+// running a trusted setup, so it can be handed to a Phase-2 MPC ceremony (mpcsetup.InitPhase2)
+// instead of BuildRecursivePlonkWrapper's single-party groth16.Setup.
+func CompileRecursivePlonkWrapper(
+	innerVk plonk.VerifyingKey,
+	innerProof plonk.Proof,
+	innerPublicInputs witness.Witness,
+	commitment succinct.Commitment,
+) (constraint.ConstraintSystem, error) {
+	circuit, err := succinct.NewRecursivePlonkWrapper(innerVk, innerProof, innerPublicInputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct recursive plonk wrapper: %w", err)
+	}
+
+	function := succinct.NewCircuitFunctionWithCommitment(circuit, commitment)
+	r1cs, err := function.Compile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile recursive plonk wrapper circuit: %w", err)
+	}
+	return r1cs, nil
+}
+
+// ParseCommitment maps the -commitment flag's value to a succinct.Commitment.
+func ParseCommitment(name string) (succinct.Commitment, error) {
+	switch name {
+	case "sha256":
+		return succinct.Sha256Truncated253{}, nil
+	case "poseidon2":
+		return succinct.Poseidon2BN254{}, nil
+	default:
+		return nil, fmt.Errorf("unknown commitment %q, expected sha256 or poseidon2", name)
+	}
+}
+
+// Export writes the outer r1cs, Groth16 keys, and FunctionVerifier.sol for the wrapper circuit.
+func (b *RecursivePlonkBuild) Export() {
+	b.build.Export()
+}
+
+// Prove generates the outer Groth16 proof that the wrapped inner PLONK proof verifies. Its input
+// bytes are the wrapper's CommitmentBytes (a sha256 digest of the inner vk followed by the inner
+// public inputs), so the committed
+// InputHash is bound to the specific inner vk and public inputs this proof wraps, not left free.
+func (b *RecursivePlonkBuild) Prove() (*types.Groth16Proof, error) {
+	inputBytes, err := b.circuit.CommitmentBytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute recursive plonk wrapper input bytes: %w", err)
+	}
+	return b.function.Prove(inputBytes, b.build)
+}